@@ -0,0 +1,102 @@
+package config
+
+import (
+	"log"
+	"strings"
+)
+
+const (
+	DocsFormatMarkdown = "markdown"
+	DocsFormatHTML     = "html"
+	DefaultDocsPath    = "./docs"
+	SortTablesByName   = "name"
+	SortTablesByRows   = "rows"
+	SortTablesBySize   = "size"
+	LintFormatText     = "text"
+	LintFormatJSON     = "json"
+	LintFormatSARIF    = "sarif"
+	LintLevelError     = "error"
+	LintLevelWarning   = "warning"
+	LintLevelOff       = "off"
+)
+
+// IsCommand whether sub command is name
+func (c *CmdParams) IsCommand(name string) bool {
+	return c.Command == name
+}
+
+// GetConfigPath yaml config path of -c option
+func (c *CmdParams) GetConfigPath() string {
+	if c.args == nil {
+		return ""
+	}
+	return c.args.YAMLPath
+}
+
+// GetDocsFormat data dictionary format (input markdown|html), default markdown
+func (c *CmdParams) GetDocsFormat() string {
+	switch strings.ToLower(c.DocsFormat) {
+	case DocsFormatHTML, "htm":
+		return DocsFormatHTML
+	default:
+		return DocsFormatMarkdown
+	}
+}
+
+// GetDocsOutPath data dictionary directory, default ./docs
+func (c *CmdParams) GetDocsOutPath() string {
+	if c.DocsOutPath != "" {
+		return c.DocsOutPath
+	}
+	return DefaultDocsPath
+}
+
+// GetSortTables sort of showTables (input name|rows|size), default name
+func (c *CmdParams) GetSortTables() string {
+	switch strings.ToLower(c.SortTables) {
+	case SortTablesByRows:
+		return SortTablesByRows
+	case SortTablesBySize:
+		return SortTablesBySize
+	default:
+		return SortTablesByName
+	}
+}
+
+// IsTableStats whether showTables print table statistics, sort by rows or size requires statistics
+func (c *CmdParams) IsTableStats() bool {
+	return c.TableStats || c.GetSortTables() != SortTablesByName
+}
+
+// GetLintFormat lint report format (input text|json|sarif), default text
+func (c *CmdParams) GetLintFormat() string {
+	switch strings.ToLower(c.LintFormat) {
+	case LintFormatJSON:
+		return LintFormatJSON
+	case LintFormatSARIF:
+		return LintFormatSARIF
+	default:
+		return LintFormatText
+	}
+}
+
+// GetLintLevels configured level of lint rules, eg: missing-comment:off => {"missing-comment": "off"}, empty level is default level of rule
+func (c *CmdParams) GetLintLevels() map[string]string {
+	var levels = make(map[string]string)
+	for _, v := range c.LintRules {
+		rule, level, _ := strings.Cut(strings.TrimSpace(v), ":")
+		switch level = strings.ToLower(strings.TrimSpace(level)); level {
+		case LintLevelError, LintLevelWarning, LintLevelOff, "":
+		default:
+			log.Printf("skip lint rule %s with unknown level %s \n", rule, level)
+			continue
+		}
+		levels[strings.TrimSpace(rule)] = level
+	}
+	return levels
+}
+
+// GetVersion version of gentool
+func (c *CmdParams) GetVersion() string {
+	return version
+}
@@ -11,35 +11,81 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
 type (
 	CmdParams struct {
-		args                  *Options `yaml:"-" json:"-"`
-		DSN                   string   `yaml:"dsn"`               // consult[https://gorm.io/docs/connecting_to_the_database.html]"
-		DB                    string   `yaml:"db"`                // input mysql or postgres or sqlite or sqlserver. consult[https://gorm.io/docs/connecting_to_the_database.html]
-		Tables                []string `yaml:"tables"`            // enter the required data table or leave it blank
-		ExcludeTableList      []string `yaml:"exclude_tables"`    // enter the exclude data table or leave it blank
-		OnlyModel             bool     `yaml:"onlyModel"`         // only generate model
-		OutPath               string   `yaml:"outPath"`           // specify a directory for output
-		OutFile               string   `yaml:"outFile"`           // query code file name, default: gen.go
-		WithUnitTest          bool     `yaml:"withUnitTest"`      // generate unit test for query code
-		ModelPkgName          string   `yaml:"modelPkgName"`      // generated model code's package name
-		FieldNullable         bool     `yaml:"fieldNullable"`     // generate with pointer when field is nullable
-		FieldCoverable        bool     `yaml:"fieldCoverable"`    // generate with pointer when field has default value
-		FieldWithIndexTag     bool     `yaml:"fieldWithIndexTag"` // generate field with gorm index tag
-		FieldWithTypeTag      bool     `yaml:"fieldWithTypeTag"`  // generate field with gorm column type tag
-		FieldSignable         bool     `yaml:"fieldSignable"`     // detect integer field's unsigned type, adjust generated data type
-		FieldJSONTypeTag      bool     `yaml:"fieldJSONTypeTag"`  // generate field with gorm json type
-		ModelNameSignable     bool     `yaml:"modelNameSignable"` // detect integer field's unsigned type, adjust generated model name
-		FieldsTypeMapping     []string `yaml:"fieldsTypeMapping"` // generate table field with gorm type
-		ImportPkgPaths        []string `yaml:"importPkgPaths"`    // generate code import package path
-		Mode                  string   `yaml:"mode"`              // generate mode (input DefaultQuery|QueryInterface|OutContext)
-		ShowTables            bool     `yaml:"-" json:"-"`        // show database tables in console
-		ShowTable             string   `yaml:"-" json:"-"`        // show table define fields in console
-		defaultYAMLConfigFile string   `json:"-" yaml:"-"`        // generate default yaml config file
-		version               string   `json:"-" yaml:"-"`
+		args                  *Options       `yaml:"-" json:"-"`
+		DSN                   string         `yaml:"dsn"`                   // consult[https://gorm.io/docs/connecting_to_the_database.html]"
+		DB                    string         `yaml:"db"`                    // input mysql or postgres or sqlite or sqlserver. consult[https://gorm.io/docs/connecting_to_the_database.html]
+		Tables                []string       `yaml:"tables"`                // enter the required data table or leave it blank
+		ExcludeTableList      []string       `yaml:"exclude_tables"`        // enter the exclude data table or leave it blank
+		OnlyModel             bool           `yaml:"onlyModel"`             // only generate model
+		OutPath               string         `yaml:"outPath"`               // specify a directory for output
+		OutFile               string         `yaml:"outFile"`               // query code file name, default: gen.go
+		WithUnitTest          bool           `yaml:"withUnitTest"`          // generate unit test for query code
+		ModelPkgName          string         `yaml:"modelPkgName"`          // generated model code's package name
+		FieldNullable         bool           `yaml:"fieldNullable"`         // generate with pointer when field is nullable
+		FieldCoverable        bool           `yaml:"fieldCoverable"`        // generate with pointer when field has default value
+		NullableStyle         string         `yaml:"nullableStyle"`         // go type of nullable field (input pointer|sqlnull|generic|guregu), default pointer by fieldNullable
+		FieldWithIndexTag     bool           `yaml:"fieldWithIndexTag"`     // generate field with gorm index tag
+		FieldWithTypeTag      bool           `yaml:"fieldWithTypeTag"`      // generate field with gorm column type tag
+		FieldSignable         bool           `yaml:"fieldSignable"`         // detect integer field's unsigned type, adjust generated data type
+		FieldJSONTypeTag      bool           `yaml:"fieldJSONTypeTag"`      // generate field with gorm json type
+		FieldWithValidateTag  bool           `yaml:"fieldWithValidateTag"`  // generate field with validate tag by column constraints
+		JSONTagNaming         string         `yaml:"jsonTagNaming"`         // json tag naming strategy (input snake|camel|lowerCamel|pascal|none), default column name
+		JSONOmitempty         string         `yaml:"jsonOmitempty"`         // json tag omitempty rule (input none|nullable|all)
+		JSONTags              []string       `yaml:"jsonTags"`              // json tag of column, eg: user_id:uid,omitempty or users.user_id:uid
+		ExtraTags             []string       `yaml:"extraTags"`             // extra tag key and naming (input column|snake|camel|lowerCamel|pascal), eg: yaml:snake, db:column
+		ModelNameSignable     bool           `yaml:"modelNameSignable"`     // detect integer field's unsigned type, adjust generated model name
+		TablePrefixes         []string       `yaml:"tablePrefixes"`         // strip table name prefix for model name, eg: t_, tbl_
+		ModelNamePrefix       string         `yaml:"modelNamePrefix"`       // generated model name prefix
+		ModelNameSuffix       string         `yaml:"modelNameSuffix"`       // generated model name suffix
+		Initialisms           []string       `yaml:"initialisms"`           // custom initialisms of model and field name, eg: ID, URL, UUID, API
+		ModelNames            []string       `yaml:"modelNames"`            // model name of table, eg: t_user_info:Account
+		PackagePrefixes       []string       `yaml:"packagePrefixes"`       // split tables into one package per prefix, eg: billing_, auth_
+		PackageGroups         []PackageGroup `yaml:"packageGroups"`         // split tables into one package per group
+		Schemas               []string       `yaml:"schemas"`               // introspect tables of schemas, postgres and sqlserver only, eg: public, billing
+		SchemaLayout          string         `yaml:"schemaLayout"`          // layout of schema tables (input package|prefix), default: package
+		WithProto             bool           `yaml:"withProto"`             // generate protobuf messages of tables and converters of models
+		ProtoOutPath          string         `yaml:"protoOutPath"`          // protobuf output directory, default: {outPath dir}/proto
+		ProtoPackage          string         `yaml:"protoPackage"`          // protobuf package, default: go package name of protoOutPath
+		ProtoGoPackage        string         `yaml:"protoGoPackage"`        // go import path of protobuf generated code, default: import path of protoOutPath
+		WithOpenAPI           bool           `yaml:"withOpenAPI"`           // generate json schema and openapi components of tables
+		OpenAPIOutPath        string         `yaml:"openAPIOutPath"`        // json schema and openapi output directory, default: {outPath dir}/openapi
+		WithTypeScript        bool           `yaml:"withTypeScript"`        // generate typescript interfaces of models
+		TypeScriptOutPath     string         `yaml:"typeScriptOutPath"`     // typescript output directory, default: {outPath dir}/types
+		TypeScriptPerTable    bool           `yaml:"typeScriptPerTable"`    // generate one .d.ts per table with index.d.ts, default one models.d.ts
+		WithGraphQL           bool           `yaml:"withGraphQL"`           // generate graphql schema and gqlgen model bindings of models
+		GraphQLOutPath        string         `yaml:"graphQLOutPath"`        // graphql output directory, default: {outPath dir}/graph
+		FieldsTypeMapping     []string       `yaml:"fieldsTypeMapping"`     // generate table field with gorm type, eg: jsonb:datatypes.JSON, tinyint(1):bool
+		TypeMappings          []TypeMapping  `yaml:"typeMappings"`          // type mappings matched by dbType, columnType, column, table and nullable, first matching wins
+		TypePreset            string         `yaml:"typePreset"`            // built-in type mappings (input pg-rich|mysql-rich|clickhouse-rich), eg: pg-rich
+		ImportPkgPaths        []string       `yaml:"importPkgPaths"`        // generate code import package path
+		Mode                  string         `yaml:"mode"`                  // generate mode (input DefaultQuery|QueryInterface|OutContext|Context, prefix ! to remove mode)
+		BatchPlaceholderLimit int32          `yaml:"batchPlaceholderLimit"` // max placeholders of one batch insert statement, default by db type
+		WithUpsert            bool           `yaml:"withUpsert"`            // generate Upsert methods on primary key and unique keys for query code
+		UpsertUpdateColumns   []string       `yaml:"upsertUpdateColumns"`   // update columns on conflict of table, eg: orders:amount,status
+		UpsertExcludeColumns  []string       `yaml:"upsertExcludeColumns"`  // columns never updated on conflict, default: created_at
+		SoftDeleteColumns     []string       `yaml:"softDeleteColumns"`     // soft delete column rule column[:time|unix|milli|nano|flag], default: deleted_at, is_deleted:flag
+		CreateTimeColumns     []string       `yaml:"createTimeColumns"`     // auto create time column rule column[:unix|milli|nano], default: created_at
+		UpdateTimeColumns     []string       `yaml:"updateTimeColumns"`     // auto update time column rule column[:unix|milli|nano], default: updated_at
+		VersionColumns        []string       `yaml:"versionColumns"`        // optimistic lock version columns, default: version
+		ShowTables            bool           `yaml:"-" json:"-"`            // show database tables in console
+		ShowTable             string         `yaml:"-" json:"-"`            // show table define fields in console
+		TableStats            bool           `yaml:"-" json:"-"`            // show row count, data size, index size and last analyzed time of tables
+		SortTables            string         `yaml:"-" json:"-"`            // sort shown tables (input name|rows|size), default: name
+		Command               string         `yaml:"-" json:"-"`            // sub command, eg: docs
+		DocsFormat            string         `yaml:"docsFormat"`            // data dictionary format of docs command (input markdown|html), default: markdown
+		DocsOutPath           string         `yaml:"docsOutPath"`           // data dictionary directory of docs command, default: ./docs
+		LintRules             []string       `yaml:"lintRules"`             // lint rule level rule:error|warning|off, eg: missing-comment:off
+		LintFormat            string         `yaml:"lintFormat"`            // lint report format (input text|json|sarif), default: text
+		LintOutPath           string         `yaml:"lintOutPath"`           // lint report file, default: stdout
+		defaultYAMLConfigFile string         `json:"-" yaml:"-"`            // generate default yaml config file
+		version               string         `json:"-" yaml:"-"`
+		typeMappings          []TypeMapping  `json:"-" yaml:"-"` // parsed type mappings
 	}
 	// YamlConfig is yaml config struct
 	YamlConfig struct {
@@ -54,6 +100,22 @@ func (d DBType) String() string {
 	return string(d)
 }
 
+// PlaceholderLimit max bind placeholders accepted by one statement
+func (d DBType) PlaceholderLimit() int32 {
+	switch d {
+	case DbPostgres:
+		return postgresPlaceholderLimit
+	case DbSQLite:
+		return sqlitePlaceholderLimit
+	case DbSQLServer:
+		return sqlServerPlaceholderLimit
+	case DbClickHouse:
+		return clickHousePlaceholderLimit
+	default:
+		return mysqlPlaceholderLimit
+	}
+}
+
 // NewFromYaml parse cmd param from yaml
 func NewFromYaml(path string) *CmdParams {
 	file, err := os.Open(path)
@@ -100,7 +162,7 @@ func (c *CmdParams) Revise() *CmdParams {
 		}
 	}
 	if c.Mode == "" {
-		c.Mode = "DefaultQuery|Context|QueryInterface"
+		c.Mode = ModeString(defaultMode)
 	}
 	if c.OutPath == "" {
 		c.OutPath = DefaultQueryPath
@@ -153,7 +215,8 @@ func (c *CmdParams) Parse() *CmdParams {
 		log.Fatalf("parse cli args fail %s", err.Error())
 		return nil
 	}
-	if args.YAMLPath == "" {
+	// validate command checks yaml config by itself, config with errors cannot be loaded
+	if args.YAMLPath == "" || args.GetCommand() == CommandValidate {
 		c.args = args
 		return c.argsParse(args)
 	}
@@ -195,12 +258,18 @@ func (c *CmdParams) argsParse(args *Options) *CmdParams {
 	if args.ModelPkgName != "" {
 		c.ModelPkgName = args.ModelPkgName
 	}
+	if args.Mode != "" {
+		c.Mode = args.Mode
+	}
 	if args.FieldNullable != nil {
 		c.FieldNullable = *args.FieldNullable
 	}
 	if args.FieldCoverable != nil {
 		c.FieldCoverable = *args.FieldCoverable
 	}
+	if args.NullableStyle != "" {
+		c.NullableStyle = args.NullableStyle
+	}
 	if args.FieldWithIndexTag != nil {
 		c.FieldWithIndexTag = *args.FieldWithIndexTag
 	}
@@ -213,24 +282,145 @@ func (c *CmdParams) argsParse(args *Options) *CmdParams {
 	if args.FieldJSONTypeTag != nil {
 		c.FieldJSONTypeTag = *args.FieldJSONTypeTag
 	}
+	if args.FieldWithValidateTag != nil {
+		c.FieldWithValidateTag = *args.FieldWithValidateTag
+	}
+	if args.JSONTagNaming != "" {
+		c.JSONTagNaming = args.JSONTagNaming
+	}
+	if args.JSONOmitempty != "" {
+		c.JSONOmitempty = args.JSONOmitempty
+	}
+	if len(args.JSONTags) > 0 {
+		c.JSONTags = args.JSONTags
+	}
+	if len(args.ExtraTags) > 0 {
+		c.ExtraTags = args.ExtraTags
+	}
 	if args.ModelNameSignable != nil {
 		c.ModelNameSignable = *args.ModelNameSignable
 	}
+	if len(args.TablePrefixes) > 0 {
+		c.TablePrefixes = args.TablePrefixes
+	}
+	if args.ModelNamePrefix != "" {
+		c.ModelNamePrefix = args.ModelNamePrefix
+	}
+	if args.ModelNameSuffix != "" {
+		c.ModelNameSuffix = args.ModelNameSuffix
+	}
+	if len(args.Initialisms) > 0 {
+		c.Initialisms = args.Initialisms
+	}
+	if len(args.ModelNames) > 0 {
+		c.ModelNames = args.ModelNames
+	}
+	if len(args.PackagePrefixes) > 0 {
+		c.PackagePrefixes = args.PackagePrefixes
+	}
+	if len(args.Schemas) > 0 {
+		c.Schemas = args.Schemas
+	}
+	if args.SchemaLayout != "" {
+		c.SchemaLayout = args.SchemaLayout
+	}
+	if args.WithProto != nil {
+		c.WithProto = *args.WithProto
+	}
+	if args.ProtoOutPath != "" {
+		c.ProtoOutPath = args.ProtoOutPath
+	}
+	if args.ProtoPackage != "" {
+		c.ProtoPackage = args.ProtoPackage
+	}
+	if args.ProtoGoPackage != "" {
+		c.ProtoGoPackage = args.ProtoGoPackage
+	}
+	if args.WithOpenAPI != nil {
+		c.WithOpenAPI = *args.WithOpenAPI
+	}
+	if args.OpenAPIOutPath != "" {
+		c.OpenAPIOutPath = args.OpenAPIOutPath
+	}
+	if args.WithTypeScript != nil {
+		c.WithTypeScript = *args.WithTypeScript
+	}
+	if args.TypeScriptOutPath != "" {
+		c.TypeScriptOutPath = args.TypeScriptOutPath
+	}
+	if args.TypeScriptPerTable != nil {
+		c.TypeScriptPerTable = *args.TypeScriptPerTable
+	}
+	if args.WithGraphQL != nil {
+		c.WithGraphQL = *args.WithGraphQL
+	}
+	if args.GraphQLOutPath != "" {
+		c.GraphQLOutPath = args.GraphQLOutPath
+	}
 	if args.ShowTables != nil {
 		c.ShowTables = *args.ShowTables
 	}
 	if args.ShowTable != "" {
 		c.ShowTable = args.ShowTable
 	}
+	if args.TableStats != nil {
+		c.TableStats = *args.TableStats
+	}
+	if args.SortTables != "" {
+		c.SortTables = args.SortTables
+	}
+	c.Command = args.GetCommand()
+	if args.DocsFormat != "" {
+		c.DocsFormat = args.DocsFormat
+	}
+	if args.DocsOutPath != "" {
+		c.DocsOutPath = args.DocsOutPath
+	}
+	if len(args.LintRules) > 0 {
+		c.LintRules = args.LintRules
+	}
+	if args.LintFormat != "" {
+		c.LintFormat = args.LintFormat
+	}
+	if args.LintOutPath != "" {
+		c.LintOutPath = args.LintOutPath
+	}
 	if args.DefaultYAMLConfigFile != "" {
 		c.defaultYAMLConfigFile = args.DefaultYAMLConfigFile
 	}
 	if len(args.FieldsTypeMapping) > 0 {
 		c.FieldsTypeMapping = args.FieldsTypeMapping
 	}
+	if args.TypePreset != "" {
+		c.TypePreset = args.TypePreset
+	}
 	if len(args.ImportPkgPaths) > 0 {
 		c.ImportPkgPaths = args.ImportPkgPaths
 	}
+	if args.BatchPlaceholderLimit > 0 {
+		c.BatchPlaceholderLimit = args.BatchPlaceholderLimit
+	}
+	if args.WithUpsert != nil {
+		c.WithUpsert = *args.WithUpsert
+	}
+	if len(args.UpsertUpdateColumns) > 0 {
+		c.UpsertUpdateColumns = args.UpsertUpdateColumns
+	}
+	if len(args.UpsertExcludeColumns) > 0 {
+		c.UpsertExcludeColumns = args.UpsertExcludeColumns
+	}
+	if len(args.SoftDeleteColumns) > 0 {
+		c.SoftDeleteColumns = args.SoftDeleteColumns
+	}
+	if len(args.CreateTimeColumns) > 0 {
+		c.CreateTimeColumns = args.CreateTimeColumns
+	}
+	if len(args.UpdateTimeColumns) > 0 {
+		c.UpdateTimeColumns = args.UpdateTimeColumns
+	}
+	if len(args.VersionColumns) > 0 {
+		c.VersionColumns = args.VersionColumns
+	}
 	return c
 }
 
@@ -241,62 +431,121 @@ func (c *CmdParams) GetDBType() DBType {
 	return DBType(c.DB)
 }
 
+// GetMode generate mode of mode option, exit on unknown mode
 func (c *CmdParams) GetMode() gen.GenerateMode {
-	if ms := strings.Split(c.Mode, "|"); len(ms) > 0 {
-		var v gen.GenerateMode = 0
-		for _, m := range ms {
-			switch m {
-			case "DefaultQuery":
-				v |= gen.WithDefaultQuery
-			case "QueryInterface":
-				v |= gen.WithQueryInterface
-			case "OutContext":
-				v |= gen.WithoutContext
-			}
-		}
-		return v
+	mode, err := ParseMode(c.Mode)
+	if err != nil {
+		log.Fatalln("invalid mode option:", err)
 	}
-	return gen.WithoutContext | gen.WithQueryInterface | gen.WithDefaultQuery
+	return mode
 }
 
-func (c *CmdParams) GetImportPkgPaths() []string {
-	if len(c.ImportPkgPaths) > 0 {
-		return c.ImportPkgPaths
+// GetPlaceholderLimit placeholder limit used to size batch inserts
+func (c *CmdParams) GetPlaceholderLimit() int32 {
+	if c.BatchPlaceholderLimit > 0 {
+		return c.BatchPlaceholderLimit
 	}
-	return []string{}
+	return c.GetDBType().PlaceholderLimit()
 }
 
-func (c *CmdParams) GetTypeMappings() map[string]func(columnType gorm.ColumnType) (dataType string) {
-	var mappings = make(map[string]func(columnType gorm.ColumnType) (dataType string))
-	if c.FieldsTypeMapping != nil {
-		for _, v := range c.FieldsTypeMapping {
-			offset := strings.Index(v, ":")
-			if offset <= 0 {
-				continue
-			}
-			name := v[:offset]
-			value := v[offset+1:]
-			if name == "" || value == "" {
-				continue
+// GetUpsertColumns update columns on conflict of table, configured columns first
+func (c *CmdParams) GetUpsertColumns(table string, columns []string) []string {
+	for _, v := range c.UpsertUpdateColumns {
+		offset := strings.Index(v, ":")
+		if offset <= 0 || strings.TrimSpace(v[:offset]) != table {
+			continue
+		}
+		var values []string
+		for _, column := range strings.Split(v[offset+1:], ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				values = append(values, column)
 			}
-			if mappings[name] != nil {
-				log.Printf("duplicate type mapping name %s \n", name)
-				continue
+		}
+		return values
+	}
+	excludes := c.UpsertExcludeColumns
+	if excludes == nil {
+		excludes = []string{defaultUpsertExcludeColumn}
+	}
+	var values = make([]string, 0, len(columns))
+	for _, column := range columns {
+		var excluded bool
+		for _, exclude := range excludes {
+			if strings.TrimSpace(exclude) == column {
+				excluded = true
+				break
 			}
-			mappings[name] = createTypeMapping(name, value)
+		}
+		if !excluded {
+			values = append(values, column)
 		}
 	}
-	if c.FieldJSONTypeTag {
-		mappings["jsonb"] = createTypeMapping("jsonb", "datatypes.JSON")
+	return values
+}
+
+func (c *CmdParams) GetImportPkgPaths() []string {
+	var paths = make([]string, 0, len(c.ImportPkgPaths)+1)
+	paths = append(paths, c.ImportPkgPaths...)
+	if len(c.GetSoftDeleteRules()) > 0 {
+		paths = append(paths, softDeletePkgPath)
+	}
+	if len(c.GetVersionColumns()) > 0 {
+		paths = append(paths, optimisticLockPkgPath)
+	}
+	var presetPaths []string
+	for _, preset := range c.typePresets() {
+		presetPaths = append(presetPaths, preset.ImportPaths...)
+	}
+	if c.GetDBType() == DbPostgres {
+		presetPaths = append(presetPaths, pqPkgPath, pgtypePkgPath)
+	}
+	switch c.GetNullableStyle() {
+	case NullableStyleSQLNull, NullableStyleGeneric:
+		presetPaths = append(presetPaths, sqlPkgPath)
+	case NullableStyleGuregu:
+		presetPaths = append(presetPaths, gureguPkgPath)
+	}
+	for _, v := range presetPaths {
+		if !slices.Contains(paths, v) {
+			paths = append(paths, v)
+		}
 	}
-	return mappings
+	return paths
 }
 
 func (c *CmdParams) GetModelOptions() []gen.ModelOpt {
-	return []gen.ModelOpt{
+	return append([]gen.ModelOpt{
 		gen.FieldGORMTagReg(`.*`, nullFieldForGo),
+		softDeleteField(c.GetSoftDeleteRules(), c.GetDBType()),
+		autoTimeField(autoCreateTimeTagKey, c.GetCreateTimeRules()),
+		autoTimeField(autoUpdateTimeTagKey, c.GetUpdateTimeRules()),
+		versionField(c.GetVersionColumns()),
+		nullableField(c.GetNullableStyle()),
 		//gen.FieldRegexCommentReplace(`\{\{.*\}\}`, replaceComment),
+	}, c.extraTagFields()...)
+}
+
+// GetTableModelOptions model options depend on table columns
+func (c *CmdParams) GetTableModelOptions(table string, columns []gorm.ColumnType) []gen.ModelOpt {
+	var (
+		opts    []gen.ModelOpt
+		indexes = make(map[string]gorm.ColumnType, len(columns))
+	)
+	var types = make(map[string]string)
+	for _, column := range columns {
+		indexes[column.Name()] = column
+		if typ, ok := c.GetColumnType(table, column); ok {
+			types[column.Name()] = typ
+		}
+	}
+	if len(types) > 0 {
+		opts = append(opts, typeMappingField(types))
+	}
+	opts = append(opts, c.jsonTagField(table, indexes))
+	if c.FieldWithValidateTag {
+		opts = append(opts, validateField(indexes, c.GetNullableStyle()))
 	}
+	return opts
 }
 
 func (c *CmdParams) IsHelp() bool {
@@ -317,7 +566,7 @@ func (c *CmdParams) GetGenDefaultYAMLFile() string {
 
 func (c *CmdParams) withDefault() *CmdParams {
 	if c.Mode == "" {
-		c.Mode = "DefaultQuery|QueryInterface|OutContext"
+		c.Mode = ModeString(defaultMode)
 	}
 	if len(c.ImportPkgPaths) <= 0 {
 		c.ImportPkgPaths = []string{"gorm.io/datatypes"}
@@ -364,16 +613,6 @@ func (c *CmdParams) withDefault() *CmdParams {
 	return c
 }
 
-func createTypeMapping(typ, value string) func(columnType gorm.ColumnType) (dataType string) {
-	return func(columnType gorm.ColumnType) (dataType string) {
-		vs, _ := columnType.ColumnType()
-		if columnType.DatabaseTypeName() == typ || vs == typ {
-			return value
-		}
-		return "string"
-	}
-}
-
 func extractDSNDBType(dsn string) string {
 	if strings.Contains(dsn, "://") {
 		schema := strings.ToLower(strings.Split(dsn, "://")[0])
@@ -442,11 +681,23 @@ func SaveYAMLConfigFile(params *CmdParams, saveFile string) (string, error) {
 			Version:  "v1",
 			Database: database,
 		}
-		data, err = yaml.Marshal(config)
+		node yaml.Node
+		data []byte
 	)
+	mode, err := ParseMode(database.Mode)
 	if err != nil {
 		return saveFile, err
 	}
+	database.Mode = ModeString(mode)
+	if err = node.Encode(config); err != nil {
+		return saveFile, err
+	}
+	if v := yamlMappingValue(yamlMappingValue(&node, "database"), "mode"); v != nil {
+		v.LineComment = ModeUsage()
+	}
+	if data, err = yaml.Marshal(&node); err != nil {
+		return saveFile, err
+	}
 	ext := strings.ToLower(filepath.Ext(saveFile))
 	if ext == "" {
 		var state os.FileInfo
@@ -470,6 +721,19 @@ func SaveYAMLConfigFile(params *CmdParams, saveFile string) (string, error) {
 	return saveFile, nil
 }
 
+// yamlMappingValue value node of key in mapping node, nil when not found
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
 func schemaPostgresToValues(dsn string) string {
 	var (
 		values  []string
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigIssue problem of yaml config at line and column of node
+type ConfigIssue struct {
+	Line    int
+	Column  int
+	Level   string // error or warning
+	Message string
+}
+
+// yamlErrorLineRegexp line of yaml.v3 error message
+var yamlErrorLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// configChecks checks of database keys after type check, value node is valid for field type
+var configChecks = map[string]func(c *configChecker, value *yaml.Node){
+	"db":                (*configChecker).checkDB,
+	"mode":              (*configChecker).checkMode,
+	"fieldsTypeMapping": (*configChecker).checkFieldsTypeMapping,
+	"typeMappings":      (*configChecker).checkTypeMappings,
+	"importPkgPaths":    (*configChecker).checkImportPkgPaths,
+}
+
+// configChecker collect issues of yaml config
+type configChecker struct {
+	issues     []ConfigIssue
+	importable func(pkgPath string) error
+}
+
+// ValidateYAMLConfig check yaml config file with schema of YamlConfig, db values, mode tokens, type mappings,
+// import paths by importable and writable output paths, unknown keys are warnings
+func ValidateYAMLConfig(path string, importable func(pkgPath string) error) ([]ConfigIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		doc     yaml.Node
+		checker = &configChecker{importable: importable}
+	)
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		// syntax errors of yaml.v3 contain line only, eg: yaml: line 3: mapping values are not allowed in this context
+		var line = 1
+		if m := yamlErrorLineRegexp.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		return []ConfigIssue{{Line: line, Column: 1, Level: LintLevelError, Message: err.Error()}}, nil
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		checker.errorf(&doc, "empty config, require database")
+		return checker.issues, nil
+	}
+	root := doc.Content[0]
+	checker.checkNode(root, reflect.TypeOf(YamlConfig{}), "")
+	database := yamlMappingValue(root, "database")
+	if database == nil {
+		if root.Kind == yaml.MappingNode {
+			checker.errorf(root, "missing key database")
+		}
+		return checker.issues, nil
+	}
+	if database.Kind != yaml.MappingNode {
+		return checker.issues, nil
+	}
+	var outPaths = map[string]bool{"outPath": false}
+	for i := 0; i+1 < len(database.Content); i += 2 {
+		key, value := database.Content[i], database.Content[i+1]
+		if check, ok := configChecks[key.Value]; ok && checker.typed(value, key.Value) {
+			check(checker, value)
+		}
+		if key.Value == "outPath" || strings.HasSuffix(key.Value, "OutPath") {
+			outPaths[key.Value] = true
+			if checker.typed(value, key.Value) && value.Value != "" {
+				checker.checkWritable(value, key.Value, value.Value)
+			}
+		}
+	}
+	if !outPaths["outPath"] {
+		checker.checkWritable(database, "outPath", DefaultQueryPath)
+	}
+	sort.SliceStable(checker.issues, func(i, j int) bool {
+		a, b := checker.issues[i], checker.issues[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return checker.issues, nil
+}
+
+// checkNode check node matches type, keys of mapping are yaml tags of struct fields
+func (c *configChecker) checkNode(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			c.errorf(node, "%s must be a mapping", configKeyName(path))
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			name := strings.TrimPrefix(path+"."+key.Value, ".")
+			field, ok := fields[key.Value]
+			if !ok {
+				c.warnf(key, "unknown key %s is ignored%s", name, configKeySuggest(key.Value, fields))
+				continue
+			}
+			c.checkNode(value, field.Type, name)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			c.errorf(node, "%s must be a list", configKeyName(path))
+			return
+		}
+		for i, item := range node.Content {
+			c.checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			c.errorf(node, "%s must be %s", configKeyName(path), t.Kind())
+			return
+		}
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			c.errorf(node, "%s must be %s, got %q", configKeyName(path), t.Kind(), node.Value)
+		}
+	}
+}
+
+// typed whether value of database key decodes into its field type, errors are reported by checkNode
+func (c *configChecker) typed(value *yaml.Node, key string) bool {
+	field, ok := yamlFields(reflect.TypeOf(CmdParams{}))[key]
+	if !ok || value.Tag == "!!null" {
+		return false
+	}
+	return value.Decode(reflect.New(field.Type).Interface()) == nil
+}
+
+func (c *configChecker) checkDB(value *yaml.Node) {
+	switch DBType(value.Value) {
+	case "", DbMySQL, DbPostgres, DbSQLite, DbSQLServer, DbClickHouse:
+	default:
+		c.errorf(value, "unknown db %q, input %s|%s|%s|%s|%s",
+			value.Value, DbMySQL, DbPostgres, DbSQLite, DbSQLServer, DbClickHouse)
+	}
+}
+
+func (c *configChecker) checkMode(value *yaml.Node) {
+	if _, err := ParseMode(value.Value); err != nil {
+		c.errorf(value, "%s", err.Error())
+	}
+}
+
+func (c *configChecker) checkFieldsTypeMapping(value *yaml.Node) {
+	for _, item := range value.Content {
+		if _, err := ParseTypeMapping(item.Value); err != nil {
+			c.errorf(item, "%s", err.Error())
+		}
+	}
+}
+
+func (c *configChecker) checkTypeMappings(value *yaml.Node) {
+	for _, item := range value.Content {
+		var m TypeMapping
+		if item.Decode(&m) != nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			c.errorf(item, "%s", err.Error())
+		}
+	}
+}
+
+func (c *configChecker) checkImportPkgPaths(value *yaml.Node) {
+	if c.importable == nil {
+		return
+	}
+	for _, item := range value.Content {
+		if err := c.importable(item.Value); err != nil {
+			c.errorf(item, "import path %q cannot be imported: %s", item.Value, err.Error())
+		}
+	}
+}
+
+// checkWritable nearest existing directory of path is writable
+func (c *configChecker) checkWritable(node *yaml.Node, key, path string) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		c.errorf(node, "%s %q is invalid: %s", key, path, err.Error())
+		return
+	}
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				dir = filepath.Dir(dir)
+				continue
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) || filepath.Dir(dir) == dir {
+			c.errorf(node, "%s %q is not writable: %s", key, path, err.Error())
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+	file, err := os.CreateTemp(dir, ".gentool-validate-*")
+	if err != nil {
+		c.errorf(node, "%s %q is not writable: %s", key, path, err.Error())
+		return
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+}
+
+func (c *configChecker) errorf(node *yaml.Node, format string, args ...interface{}) {
+	c.issues = append(c.issues, ConfigIssue{Line: node.Line, Column: node.Column, Level: LintLevelError, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *configChecker) warnf(node *yaml.Node, format string, args ...interface{}) {
+	c.issues = append(c.issues, ConfigIssue{Line: node.Line, Column: node.Column, Level: LintLevelWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// yamlFields struct fields by yaml key, fields ignored by yaml are excluded
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	var fields = make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// configKeySuggest known key differs from key only in case, eg: outpath => outPath
+func configKeySuggest(key string, fields map[string]reflect.StructField) string {
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return fmt.Sprintf(", did you mean %s", name)
+		}
+	}
+	return ""
+}
+
+func configKeyName(path string) string {
+	if path == "" {
+		return "config"
+	}
+	return path
+}
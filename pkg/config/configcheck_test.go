@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateYAMLConfig(t *testing.T) {
+	var (
+		dir        = t.TempDir()
+		outPath    = filepath.Join(dir, "dao", "query")
+		importable = func(pkgPath string) error {
+			if strings.HasPrefix(pkgPath, "example.com/missing") {
+				return errors.New("no required module provides package")
+			}
+			return nil
+		}
+	)
+	cases := []struct {
+		name   string
+		config string
+		want   []string // line:column level message prefix
+	}{
+		{
+			name:   "valid",
+			config: "database:\n  dsn: root@tcp(localhost:3306)/db\n  db: mysql\n  outPath: " + outPath + "\n",
+		},
+		{
+			name:   "syntax error",
+			config: "database:\n  dsn: a\n   db: mysql\n",
+			want:   []string{"3:1 error yaml:"},
+		},
+		{
+			name:   "missing database",
+			config: "tables: users\n",
+			want:   []string{"1:1 warning unknown key tables is ignored", "1:1 error missing key database"},
+		},
+		{
+			name:   "unknown key with suggestion",
+			config: "database:\n  outpath: " + outPath + "\n  outPath: " + outPath + "\n",
+			want:   []string{"2:3 warning unknown key database.outpath is ignored, did you mean outPath"},
+		},
+		{
+			name:   "wrong types",
+			config: "database:\n  outPath: " + outPath + "\n  onlyModel: maybe\n  tables: users\n  batchPlaceholderLimit: many\n",
+			want: []string{
+				`3:14 error database.onlyModel must be bool, got "maybe"`,
+				"4:11 error database.tables must be a list",
+				`5:26 error database.batchPlaceholderLimit must be int32, got "many"`,
+			},
+		},
+		{
+			name:   "values",
+			config: "database:\n  outPath: " + outPath + "\n  db: oracle\n  mode: DefaultQuery|Fast\n  fieldsTypeMapping:\n    - jsonb\n",
+			want: []string{
+				`3:7 error unknown db "oracle"`,
+				`4:9 error unknown mode "Fast"`,
+				`6:7 error invalid type mapping "jsonb"`,
+			},
+		},
+		{
+			name:   "type mappings",
+			config: "database:\n  outPath: " + outPath + "\n  typeMappings:\n    - dbType: json\n    - column: is_*\n      type: bool\n      Nullable: true\n      nullabel: true\n",
+			want: []string{
+				"4:7 error type mapping json => ",
+				"7:7 warning unknown key database.typeMappings[1].Nullable is ignored, did you mean nullable",
+				"8:7 warning unknown key database.typeMappings[1].nullabel is ignored",
+			},
+		},
+		{
+			name:   "import paths",
+			config: "database:\n  outPath: " + outPath + "\n  importPkgPaths:\n    - github.com/google/uuid\n    - example.com/missing/pkg\n",
+			want:   []string{`5:7 error import path "example.com/missing/pkg" cannot be imported`},
+		},
+		{
+			name:   "output path not writable",
+			config: "database:\n  outPath: " + filepath.Join(dir, "file.txt", "query") + "\n",
+			want:   []string{"2:12 error outPath"},
+		},
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), nil, 0640); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("gen%d.yaml", i))
+			if err := os.WriteFile(path, []byte(c.config), 0640); err != nil {
+				t.Fatal(err)
+			}
+			issues, err := ValidateYAMLConfig(path, importable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got = make([]string, 0, len(issues))
+			for _, issue := range issues {
+				got = append(got, fmt.Sprintf("%d:%d %s %s", issue.Line, issue.Column, issue.Level, issue.Message))
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("issues = %q, want %q", got, c.want)
+			}
+			for j := range got {
+				if !strings.HasPrefix(got[j], c.want[j]) {
+					t.Errorf("issue %d = %q, want prefix %q", j, got[j], c.want[j])
+				}
+			}
+		})
+	}
+	if _, err := ValidateYAMLConfig(filepath.Join(dir, "missing.yaml"), nil); err == nil {
+		t.Errorf("missing config file must fail")
+	}
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	gen "gorm.io/gen"
+)
+
+const (
+	// soft delete modes of gorm.io/plugin/soft_delete, time means gorm.DeletedAt
+	softDeleteTime  = "time"
+	softDeleteUnix  = "unix"
+	softDeleteMilli = "milli"
+	softDeleteNano  = "nano"
+	softDeleteFlag  = "flag"
+
+	softDeletePkgPath = "gorm.io/plugin/soft_delete"
+	softDeleteTagKey  = "softDelete"
+
+	// auto create/update time modes of integer columns, default unix second
+	autoTimeUnix  = "unix"
+	autoTimeMilli = "milli"
+	autoTimeNano  = "nano"
+
+	autoCreateTimeTagKey = "autoCreateTime"
+	autoUpdateTimeTagKey = "autoUpdateTime"
+
+	optimisticLockPkgPath = "gorm.io/plugin/optimisticlock"
+)
+
+var (
+	// defaultSoftDeleteColumns soft delete column rules when not configured
+	defaultSoftDeleteColumns = []string{"deleted_at", "is_deleted:flag"}
+	// defaultCreateTimeColumns auto create time column rules when not configured
+	defaultCreateTimeColumns = []string{"created_at"}
+	// defaultUpdateTimeColumns auto update time column rules when not configured
+	defaultUpdateTimeColumns = []string{"updated_at"}
+	// defaultVersionColumns optimistic lock column rules when not configured
+	defaultVersionColumns = []string{"version"}
+)
+
+// GetSoftDeleteRules soft delete column to mode, empty mode is detected by column type
+func (c *CmdParams) GetSoftDeleteRules() map[string]string {
+	columns := c.SoftDeleteColumns
+	if columns == nil {
+		columns = defaultSoftDeleteColumns
+	}
+	return parseColumnRules("soft delete", columns,
+		softDeleteTime, softDeleteUnix, softDeleteMilli, softDeleteNano, softDeleteFlag)
+}
+
+// GetCreateTimeRules auto create time column to mode, empty mode is detected by column type
+func (c *CmdParams) GetCreateTimeRules() map[string]string {
+	columns := c.CreateTimeColumns
+	if columns == nil {
+		columns = defaultCreateTimeColumns
+	}
+	return parseColumnRules("create time", columns, autoTimeUnix, autoTimeMilli, autoTimeNano)
+}
+
+// GetUpdateTimeRules auto update time column to mode, empty mode is detected by column type
+func (c *CmdParams) GetUpdateTimeRules() map[string]string {
+	columns := c.UpdateTimeColumns
+	if columns == nil {
+		columns = defaultUpdateTimeColumns
+	}
+	return parseColumnRules("update time", columns, autoTimeUnix, autoTimeMilli, autoTimeNano)
+}
+
+// GetVersionColumns optimistic lock columns
+func (c *CmdParams) GetVersionColumns() map[string]string {
+	columns := c.VersionColumns
+	if columns == nil {
+		columns = defaultVersionColumns
+	}
+	return parseColumnRules("version", columns)
+}
+
+// parseColumnRules parse column[:mode] rules to column and mode
+func parseColumnRules(kind string, columns []string, modes ...string) map[string]string {
+	var rules = make(map[string]string)
+	for _, v := range columns {
+		column, mode := strings.TrimSpace(v), ""
+		if offset := strings.Index(column, ":"); offset > 0 {
+			column, mode = strings.TrimSpace(column[:offset]), strings.TrimSpace(column[offset+1:])
+		}
+		if column == "" {
+			continue
+		}
+		if mode != "" && !slices.Contains(modes, mode) {
+			log.Printf("unknown %s mode %s of column %s \n", kind, mode, column)
+			continue
+		}
+		rules[column] = mode
+	}
+	return rules
+}
+
+// softDeleteField use gorm.DeletedAt or soft_delete.DeletedAt for soft delete columns, unsupported columns are logged
+func softDeleteField(rules map[string]string, dbType DBType) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		mode, ok := rules[f.ColumnName]
+		if !ok {
+			return f
+		}
+		typ, tag, err := softDeleteType(strings.TrimPrefix(f.Type, "*"), mode, dbType)
+		if err != nil {
+			log.Printf("skip soft delete column %s: %s \n", f.ColumnName, err.Error())
+			return f
+		}
+		f.Type = typ
+		if tag != "" {
+			f.GORMTag.Set(softDeleteTagKey, tag)
+		}
+		return f
+	})
+}
+
+// softDeleteType soft delete go type and softDelete tag of field type with mode
+func softDeleteType(typ, mode string, dbType DBType) (string, string, error) {
+	switch {
+	case typ == "time.Time" || typ == "gorm.DeletedAt":
+		if mode == "" || mode == softDeleteTime {
+			return "gorm.DeletedAt", "", nil
+		}
+	case isIntegerType(typ):
+		switch mode {
+		case "", softDeleteUnix:
+			return "soft_delete.DeletedAt", "", nil
+		case softDeleteMilli, softDeleteNano, softDeleteFlag:
+			return "soft_delete.DeletedAt", mode, nil
+		}
+	case typ == "bool":
+		// flag of soft_delete.DeletedAt is stored as 0/1, postgres boolean does not accept integers
+		if dbType == DbPostgres {
+			return "", "", fmt.Errorf("flag of type %s is stored as integer, use smallint column on postgres", typ)
+		}
+		if mode == "" || mode == softDeleteFlag {
+			return "soft_delete.DeletedAt", softDeleteFlag, nil
+		}
+	}
+	if mode == "" {
+		return "", "", fmt.Errorf("type %s is not time, integer or bool", typ)
+	}
+	return "", "", fmt.Errorf("type %s does not support mode %s", typ, mode)
+}
+
+// autoTimeField set autoCreateTime/autoUpdateTime tag for time columns
+func autoTimeField(tagKey string, rules map[string]string) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		mode, ok := rules[f.ColumnName]
+		if !ok {
+			return f
+		}
+		switch typ := strings.TrimPrefix(f.Type, "*"); {
+		case typ == "time.Time":
+			if mode == "" {
+				f.GORMTag.Set(tagKey)
+			}
+		case isIntegerType(typ):
+			if mode == "" || mode == autoTimeUnix {
+				f.GORMTag.Set(tagKey)
+			} else {
+				f.GORMTag.Set(tagKey, mode)
+			}
+		}
+		return f
+	})
+}
+
+// versionField use optimisticlock.Version for optimistic lock columns
+func versionField(rules map[string]string) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		if _, ok := rules[f.ColumnName]; ok && isIntegerType(strings.TrimPrefix(f.Type, "*")) {
+			f.Type = "optimisticlock.Version"
+		}
+		return f
+	})
+}
+
+func isIntegerType(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestSoftDeleteType(t *testing.T) {
+	cases := []struct {
+		name   string
+		typ    string
+		mode   string
+		dbType DBType
+		want   string
+		tag    string
+		error  bool
+	}{
+		{name: "time", typ: "time.Time", want: "gorm.DeletedAt"},
+		{name: "time mode", typ: "time.Time", mode: softDeleteTime, want: "gorm.DeletedAt"},
+		{name: "deleted at", typ: "gorm.DeletedAt", want: "gorm.DeletedAt"},
+		{name: "time with unix", typ: "time.Time", mode: softDeleteUnix, error: true},
+		{name: "int64", typ: "int64", want: "soft_delete.DeletedAt"},
+		{name: "uint unix", typ: "uint", mode: softDeleteUnix, want: "soft_delete.DeletedAt"},
+		{name: "int64 milli", typ: "int64", mode: softDeleteMilli, want: "soft_delete.DeletedAt", tag: softDeleteMilli},
+		{name: "int64 nano", typ: "int64", mode: softDeleteNano, want: "soft_delete.DeletedAt", tag: softDeleteNano},
+		{name: "int32 flag", typ: "int32", mode: softDeleteFlag, want: "soft_delete.DeletedAt", tag: softDeleteFlag},
+		{name: "int with time", typ: "int64", mode: softDeleteTime, error: true},
+		{name: "mysql tinyint(1) flag", typ: "bool", mode: softDeleteFlag, dbType: DbMySQL, want: "soft_delete.DeletedAt", tag: softDeleteFlag},
+		{name: "sqlite bool", typ: "bool", dbType: DbSQLite, want: "soft_delete.DeletedAt", tag: softDeleteFlag},
+		{name: "bool with unix", typ: "bool", mode: softDeleteUnix, dbType: DbMySQL, error: true},
+		{name: "postgres boolean flag", typ: "bool", mode: softDeleteFlag, dbType: DbPostgres, error: true},
+		{name: "string", typ: "string", mode: softDeleteFlag, error: true},
+		{name: "string without mode", typ: "string", error: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, tag, err := softDeleteType(c.typ, c.mode, c.dbType)
+			if (err != nil) != c.error {
+				t.Fatalf("softDeleteType(%s, %s) error = %v, want error %v", c.typ, c.mode, err, c.error)
+			}
+			if got != c.want || tag != c.tag {
+				t.Errorf("softDeleteType(%s, %s) = %q, %q, want %q, %q", c.typ, c.mode, got, tag, c.want, c.tag)
+			}
+		})
+	}
+}
@@ -16,24 +16,81 @@ type Options struct {
 	OnlyModel             *bool    `env:"GEN_ONLY_MODEL" json:"onlyModel" long:"onlyModel" description:"only generate models (without query file)"`
 	OutPath               string   `env:"GEN_OUT_PATH" json:"outPath" long:"outPath" description:"specify a directory for output"`
 	OutFile               string   `env:"GEN_OUTFILE" json:"outFile" long:"outFile" description:"query code file name, default: gen.go" default:"gen.go"`
-	Mode                  string   `env:"GEN_MODE" json:"mode" long:"mode" description:"input DefaultQuery|QueryInterface|OutContext. gen mode setting"`
+	Mode                  string   `env:"GEN_MODE" json:"mode" long:"mode" description:"gen mode setting, input DefaultQuery|QueryInterface|OutContext|Context, prefix ! to remove mode, eg: DefaultQuery|QueryInterface or !OutContext, default: DefaultQuery|QueryInterface|OutContext"`
 	WithUnitTest          *bool    `env:"GEN_WITH_UNITTEST" json:"withUnitTest" long:"withUnitTest" description:"generate unit test for query code"`
 	ModelPkgName          string   `env:"GEN_MODEL_PKG_NAME" json:"modelPkgName" long:"modelPkgName" description:"generated model code's package name"`
 	FieldNullable         *bool    `env:"GEN_FIELD_NULLABLE" json:"fieldNullable" long:"fieldNullable" description:"generate with pointer when field is nullable"`
 	FieldCoverable        *bool    `env:"GEN_FIELD_COVERABLE" json:"fieldCoverable" long:"fieldCoverable" description:"generate with pointer when field has default value"`
+	NullableStyle         string   `env:"GEN_NULLABLE_STYLE" json:"nullableStyle" long:"nullableStyle" description:"go type of nullable field, input pointer|sqlnull|generic|guregu, default pointer by fieldNullable"`
 	FieldWithIndexTag     *bool    `env:"GEN_FIELD_WITH_INDEX_TAG" json:"fieldWithIndexTag" long:"fieldWithIndexTag" description:"generate field with gorm index tag"`
 	FieldWithTypeTag      *bool    `env:"GEN_FIELD_WITH_TYPE_TAG" json:"fieldWithTypeTag" long:"fieldWithTypeTag" description:"generate field with gorm column type tag"`
 	FieldSignable         *bool    `env:"GEN_FIELD_SIGNABLE" json:"fieldSignable" long:"fieldSignable" description:"detect integer field's unsigned type, adjust generated data type"`
 	ModelNameSignable     *bool    `env:"GEN_MODEL_NAME_SIGNABLE" json:"modelNameSignable" long:"modelNameSignable" description:"keep model names and table names consistent, without using plural rewriting"`
+	TablePrefixes         []string `env:"GEN_TABLE_PREFIXES" json:"tablePrefixes" long:"tablePrefixes" description:"strip table name prefix for model name,eg: t_,tbl_"`
+	ModelNamePrefix       string   `env:"GEN_MODEL_NAME_PREFIX" json:"modelNamePrefix" long:"modelNamePrefix" description:"generated model name prefix"`
+	ModelNameSuffix       string   `env:"GEN_MODEL_NAME_SUFFIX" json:"modelNameSuffix" long:"modelNameSuffix" description:"generated model name suffix"`
+	Initialisms           []string `env:"GEN_INITIALISMS" json:"initialisms" long:"initialisms" description:"custom initialisms of model and field name,eg: ID,URL,UUID,API"`
+	ModelNames            []string `env:"GEN_MODEL_NAMES" json:"modelNames" long:"modelNames" description:"model name of table,eg: t_user_info:Account"`
+	PackagePrefixes       []string `env:"GEN_PACKAGE_PREFIXES" json:"packagePrefixes" long:"packagePrefixes" description:"split tables into one package per prefix,eg: billing_,auth_"`
+	Schemas               []string `env:"GEN_SCHEMAS" json:"schemas" long:"schemas" description:"introspect tables of schemas, postgres and sqlserver only,eg: public,billing"`
+	SchemaLayout          string   `env:"GEN_SCHEMA_LAYOUT" json:"schemaLayout" long:"schemaLayout" description:"layout of schema tables, input package|prefix, default: package"`
+	WithProto             *bool    `env:"GEN_WITH_PROTO" json:"withProto" long:"withProto" description:"generate protobuf messages of tables and converters of models"`
+	ProtoOutPath          string   `env:"GEN_PROTO_OUT_PATH" json:"protoOutPath" long:"protoOutPath" description:"protobuf output directory, default: {outPath dir}/proto"`
+	ProtoPackage          string   `env:"GEN_PROTO_PACKAGE" json:"protoPackage" long:"protoPackage" description:"protobuf package, default: go package name of protoOutPath"`
+	ProtoGoPackage        string   `env:"GEN_PROTO_GO_PACKAGE" json:"protoGoPackage" long:"protoGoPackage" description:"go import path of protobuf generated code, default: import path of protoOutPath"`
+	WithOpenAPI           *bool    `env:"GEN_WITH_OPENAPI" json:"withOpenAPI" long:"withOpenAPI" description:"generate json schema and openapi components of tables"`
+	OpenAPIOutPath        string   `env:"GEN_OPENAPI_OUT_PATH" json:"openAPIOutPath" long:"openAPIOutPath" description:"json schema and openapi output directory, default: {outPath dir}/openapi"`
+	WithTypeScript        *bool    `env:"GEN_WITH_TYPESCRIPT" json:"withTypeScript" long:"withTypeScript" description:"generate typescript interfaces of models"`
+	TypeScriptOutPath     string   `env:"GEN_TYPESCRIPT_OUT_PATH" json:"typeScriptOutPath" long:"typeScriptOutPath" description:"typescript output directory, default: {outPath dir}/types"`
+	TypeScriptPerTable    *bool    `env:"GEN_TYPESCRIPT_PER_TABLE" json:"typeScriptPerTable" long:"typeScriptPerTable" description:"generate one .d.ts per table with index.d.ts, default one models.d.ts"`
+	WithGraphQL           *bool    `env:"GEN_WITH_GRAPHQL" json:"withGraphQL" long:"withGraphQL" description:"generate graphql schema and gqlgen model bindings of models"`
+	GraphQLOutPath        string   `env:"GEN_GRAPHQL_OUT_PATH" json:"graphQLOutPath" long:"graphQLOutPath" description:"graphql output directory, default: {outPath dir}/graph"`
 	FieldJSONTypeTag      *bool    `env:"GEN_FIELD_JSON_TYPE_TAG" json:"fieldJSONTypeTag" long:"fieldJSONTypeTag" description:"generate field with gorm json type"`
-	FieldsTypeMapping     []string `env:"GEN_FIELDS_TYPE_MAPPING" json:"fieldsTypeMapping" long:"fieldsTypeMapping" short:"m" description:"mapping field type mapping ,eg: jsonb:datatypes.JSON"`
+	FieldWithValidateTag  *bool    `env:"GEN_FIELD_WITH_VALIDATE_TAG" json:"fieldWithValidateTag" long:"fieldWithValidateTag" description:"generate field with validate tag by column constraints"`
+	JSONTagNaming         string   `env:"GEN_JSON_TAG_NAMING" json:"jsonTagNaming" long:"jsonTagNaming" description:"json tag naming strategy, input snake|camel|lowerCamel|pascal|none"`
+	JSONOmitempty         string   `env:"GEN_JSON_OMITEMPTY" json:"jsonOmitempty" long:"jsonOmitempty" description:"json tag omitempty rule, input none|nullable|all"`
+	JSONTags              []string `env:"GEN_JSON_TAGS" json:"jsonTags" long:"jsonTags" description:"json tag of column,eg: user_id:uid,omitempty or users.user_id:uid"`
+	ExtraTags             []string `env:"GEN_EXTRA_TAGS" json:"extraTags" long:"extraTags" description:"extra tag key and naming(column|snake|camel|lowerCamel|pascal),eg: yaml:snake,db:column"`
+	FieldsTypeMapping     []string `env:"GEN_FIELDS_TYPE_MAPPING" json:"fieldsTypeMapping" long:"fieldsTypeMapping" short:"m" description:"mapping field type mapping ,eg: jsonb:datatypes.JSON,tinyint(1):bool"`
+	TypePreset            string   `env:"GEN_TYPE_PRESET" json:"typePreset" long:"typePreset" description:"built-in type mappings, input pg-rich|mysql-rich|clickhouse-rich, eg: pg-rich"`
 	ImportPkgPaths        []string `env:"GEN_IMPORT_PKG_PATHS" json:"importPkgPaths" long:"importPkgPaths" short:"p" description:"generate code import package path,eg: github.com/xxx/xxx"`
+	BatchPlaceholderLimit int32    `env:"GEN_BATCH_PLACEHOLDER_LIMIT" json:"batchPlaceholderLimit" long:"batchPlaceholderLimit" description:"max placeholders of one batch insert statement, default by db type"`
+	WithUpsert            *bool    `env:"GEN_WITH_UPSERT" json:"withUpsert" long:"withUpsert" description:"generate Upsert methods on primary key and unique keys for query code"`
+	UpsertUpdateColumns   []string `env:"GEN_UPSERT_UPDATE_COLUMNS" json:"upsertUpdateColumns" long:"upsertUpdateColumns" description:"update columns on conflict of table,eg: orders:amount,status"`
+	UpsertExcludeColumns  []string `env:"GEN_UPSERT_EXCLUDE_COLUMNS" json:"upsertExcludeColumns" long:"upsertExcludeColumns" description:"columns never updated on conflict, default: created_at"`
+	SoftDeleteColumns     []string `env:"GEN_SOFT_DELETE_COLUMNS" json:"softDeleteColumns" long:"softDeleteColumns" description:"soft delete column rule,eg: deleted_at,is_deleted:flag (mode: time|unix|milli|nano|flag)"`
+	CreateTimeColumns     []string `env:"GEN_CREATE_TIME_COLUMNS" json:"createTimeColumns" long:"createTimeColumns" description:"auto create time column rule,eg: created_at,create_ms:milli (mode: unix|milli|nano)"`
+	UpdateTimeColumns     []string `env:"GEN_UPDATE_TIME_COLUMNS" json:"updateTimeColumns" long:"updateTimeColumns" description:"auto update time column rule,eg: updated_at,update_ms:milli (mode: unix|milli|nano)"`
+	VersionColumns        []string `env:"GEN_VERSION_COLUMNS" json:"versionColumns" long:"versionColumns" description:"optimistic lock version columns, default: version"`
 	DefaultYAMLConfigFile string   `env:"GEN_Config_File" json:"defaultYAMLConfigFile" long:"defaultYAMLConfigFile" short:"d" description:"generate default yaml config file"`
 	V                     *bool    `json:"version" long:"version" short:"v" description:"print tool version"`
 	ShowTables            *bool    `json:"showTables" long:"showTables" short:"s" description:"show database tables in console"`
 	ShowTable             string   `json:"showTable" long:"showTable"  description:"show table define fields in console"`
+	TableStats            *bool    `json:"tableStats" long:"tableStats" description:"show row count, data size, index size and last analyzed time of tables with showTables"`
+	SortTables            string   `json:"sortTables" long:"sortTables" description:"sort tables of showTables, input name|rows|size, default: name"`
+	DocsFormat            string   `env:"GEN_DOCS_FORMAT" json:"docsFormat" long:"docsFormat" description:"data dictionary format of docs command, input markdown|html, default: markdown"`
+	DocsOutPath           string   `env:"GEN_DOCS_OUT_PATH" json:"docsOutPath" long:"docsOutPath" description:"data dictionary directory of docs command, default: ./docs"`
+	LintRules             []string `env:"GEN_LINT_RULES" json:"lintRules" long:"lintRules" description:"lint rule level of lint command,eg: missing-comment:off,nullable-without-default:error (level: error|warning|off)"`
+	LintFormat            string   `env:"GEN_LINT_FORMAT" json:"lintFormat" long:"lintFormat" description:"lint report format of lint command, input text|json|sarif, default: text"`
+	LintOutPath           string   `env:"GEN_LINT_OUT_PATH" json:"lintOutPath" long:"lintOutPath" description:"lint report file of lint command, default: stdout"`
 	helpMsg               bool
 	rowValues             []string
+	command               string
+}
+
+const (
+	CommandDocs     = "docs"
+	CommandLint     = "lint"
+	CommandValidate = "validate"
+)
+
+// commands sub commands of gentool, options are shared with generate
+var commands = []struct {
+	name, short, long string
+}{
+	{CommandDocs, "generate data dictionary", "write data dictionary of tables as markdown or html into docsOutPath"},
+	{CommandLint, "check schema with lint rules", "check tables with lint rules, exit with non-zero code on violations"},
+	{CommandValidate, "validate yaml config", "check yaml config of -c with keys, types and values, exit with non-zero code on errors"},
 }
 
 func NewOptions() *Options {
@@ -41,8 +98,18 @@ func NewOptions() *Options {
 }
 
 func (f *Options) Parse() (*Options, error) {
-	_, err := flags.Parse(f)
+	parser := flags.NewParser(f, flags.Default)
+	parser.SubcommandsOptional = true
+	for _, c := range commands {
+		if _, err := parser.AddCommand(c.name, c.short, c.long, &struct{}{}); err != nil {
+			return nil, err
+		}
+	}
+	_, err := parser.Parse()
 	f.rowValues = os.Args[1:]
+	if parser.Active != nil {
+		f.command = parser.Active.Name
+	}
 	if err != nil {
 		if errors.Is(err.(*flags.Error).Type, flags.ErrRequired) &&
 			f.DefaultYAMLConfigFile != "" {
@@ -68,3 +135,8 @@ func (f *Options) GetRawArgs() []string {
 func (f *Options) GetHelpMsg() bool {
 	return f.helpMsg
 }
+
+// GetCommand sub command name, empty for generate
+func (f *Options) GetCommand() string {
+	return f.command
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	gen "gorm.io/gen"
+)
+
+const (
+	ModeDefaultQuery   = "DefaultQuery"
+	ModeQueryInterface = "QueryInterface"
+	ModeOutContext     = "OutContext"
+	ModeContext        = "Context"
+
+	// modeNegation prefix of mode token removing the mode, eg: !QueryInterface
+	modeNegation = "!"
+	modeSep      = "|"
+)
+
+// defaultMode generate mode of empty mode option and mode starts with negation
+const defaultMode = gen.WithDefaultQuery | gen.WithQueryInterface | gen.WithoutContext
+
+// modeTokens tokens of generate mode in order, Context is the negation of OutContext
+var modeTokens = []string{ModeDefaultQuery, ModeQueryInterface, ModeOutContext, ModeContext}
+
+// ModeUsage usage of mode option for help and generated yaml
+func ModeUsage() string {
+	return "input " + strings.Join(modeTokens, modeSep) + ", prefix " + modeNegation + " to remove mode, eg: " +
+		ModeDefaultQuery + modeSep + ModeQueryInterface + " or " + modeNegation + ModeOutContext
+}
+
+// ParseMode parse generate mode of | separated case-insensitive tokens, token with ! prefix removes the mode,
+// mode starts with negation is applied on default mode, eg: DefaultQuery|QueryInterface, !OutContext
+func ParseMode(v string) (gen.GenerateMode, error) {
+	if strings.TrimSpace(v) == "" {
+		return defaultMode, nil
+	}
+	var mode gen.GenerateMode
+	for i, token := range strings.Split(v, modeSep) {
+		token = strings.TrimSpace(token)
+		negation := strings.HasPrefix(token, modeNegation)
+		if negation {
+			token = strings.TrimSpace(strings.TrimPrefix(token, modeNegation))
+			if i == 0 {
+				mode = defaultMode
+			}
+		}
+		var (
+			bit     gen.GenerateMode
+			inverse bool
+		)
+		switch {
+		case strings.EqualFold(token, ModeDefaultQuery):
+			bit = gen.WithDefaultQuery
+		case strings.EqualFold(token, ModeQueryInterface):
+			bit = gen.WithQueryInterface
+		case strings.EqualFold(token, ModeOutContext):
+			bit = gen.WithoutContext
+		case strings.EqualFold(token, ModeContext):
+			bit, inverse = gen.WithoutContext, true
+		case token == "":
+			return 0, fmt.Errorf("empty mode in %q, %s", v, ModeUsage())
+		default:
+			return 0, fmt.Errorf("unknown mode %q in %q, %s", token, v, ModeUsage())
+		}
+		if negation != inverse {
+			mode &^= bit
+		} else {
+			mode |= bit
+		}
+	}
+	return mode, nil
+}
+
+// ModeString canonical tokens of generate mode, eg: DefaultQuery|QueryInterface|OutContext
+func ModeString(mode gen.GenerateMode) string {
+	var tokens []string
+	if mode&gen.WithDefaultQuery != 0 {
+		tokens = append(tokens, ModeDefaultQuery)
+	}
+	if mode&gen.WithQueryInterface != 0 {
+		tokens = append(tokens, ModeQueryInterface)
+	}
+	if mode&gen.WithoutContext != 0 {
+		tokens = append(tokens, ModeOutContext)
+	} else {
+		tokens = append(tokens, ModeContext)
+	}
+	return strings.Join(tokens, modeSep)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	gen "gorm.io/gen"
+)
+
+func TestParseMode(t *testing.T) {
+	cases := []struct {
+		name  string
+		mode  string
+		want  gen.GenerateMode
+		error string
+	}{
+		{name: "empty", mode: "", want: defaultMode},
+		{name: "blank", mode: "  ", want: defaultMode},
+		{name: "tokens", mode: "DefaultQuery|QueryInterface", want: gen.WithDefaultQuery | gen.WithQueryInterface},
+		{name: "case-insensitive", mode: "defaultquery | OUTCONTEXT", want: gen.WithDefaultQuery | gen.WithoutContext},
+		{name: "context", mode: "DefaultQuery|OutContext|Context", want: gen.WithDefaultQuery},
+		{name: "negation on default", mode: "!OutContext", want: gen.WithDefaultQuery | gen.WithQueryInterface},
+		{name: "negation of context", mode: "!Context", want: defaultMode},
+		{name: "negation after token", mode: "DefaultQuery|!DefaultQuery|QueryInterface", want: gen.WithQueryInterface},
+		{name: "negation with space", mode: "! QueryInterface", want: gen.WithDefaultQuery | gen.WithoutContext},
+		{name: "unknown token", mode: "DefaultQuery|Fast", error: `unknown mode "Fast"`},
+		{name: "unknown negation", mode: "!Fast", error: `unknown mode "Fast"`},
+		{name: "empty token", mode: "DefaultQuery||QueryInterface", error: "empty mode"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ParseMode(c.mode)
+			if c.error != "" {
+				if err == nil || !strings.Contains(err.Error(), c.error) {
+					t.Fatalf("ParseMode(%q) error = %v, want %q", c.mode, err, c.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMode(%q) error = %v", c.mode, err)
+			}
+			if got != c.want {
+				t.Errorf("ParseMode(%q) = %s, want %s", c.mode, ModeString(got), ModeString(c.want))
+			}
+		})
+	}
+}
+
+func TestDefaultModeConsistent(t *testing.T) {
+	want := ModeString(defaultMode)
+	if got := (&CmdParams{}).withDefault().Mode; got != want {
+		t.Errorf("withDefault() mode = %q, want %q", got, want)
+	}
+	if mode, err := ParseMode(want); err != nil || mode != defaultMode {
+		t.Errorf("ParseMode(%q) = %s, %v, want default mode", want, ModeString(mode), err)
+	}
+}
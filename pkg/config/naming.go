@@ -0,0 +1,300 @@
+package config
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	gen "gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// json tag naming strategies
+	JSONTagSnake      = "snake"
+	JSONTagCamel      = "camel"
+	JSONTagLowerCamel = "lowerCamel"
+	JSONTagPascal     = "pascal"
+	JSONTagNone       = "none"
+	// TagNamingColumn tag value is column name
+	TagNamingColumn = "column"
+
+	// json omitempty rules
+	JSONOmitemptyNone     = "none"
+	JSONOmitemptyNullable = "nullable"
+	JSONOmitemptyAll      = "all"
+
+	jsonOmitempty = "omitempty"
+)
+
+// GetJSONTagNameStrategy json tag name strategy of column, nil means gen default (column name)
+func (c *CmdParams) GetJSONTagNameStrategy() func(columnName string) string {
+	if c.JSONTagNaming == "" {
+		return nil
+	}
+	if c.JSONTagNaming == JSONTagNone {
+		return func(string) string { return "" }
+	}
+	ns, ok := tagNameStrategy(c.JSONTagNaming)
+	if !ok {
+		log.Printf("unknown json tag naming %s \n", c.JSONTagNaming)
+		return nil
+	}
+	return ns
+}
+
+// extraTagFields add extra tags with name strategy, eg: yaml:snake or bson:snake,omitempty
+func (c *CmdParams) extraTagFields() []gen.ModelOpt {
+	var opts []gen.ModelOpt
+	for _, v := range c.ExtraTags {
+		offset := strings.Index(v, ":")
+		if offset <= 0 {
+			log.Printf("invalid extra tag %s \n", v)
+			continue
+		}
+		key, naming := strings.TrimSpace(v[:offset]), strings.TrimSpace(v[offset+1:])
+		naming, options, _ := strings.Cut(naming, ",")
+		ns, ok := tagNameStrategy(naming)
+		if !ok {
+			log.Printf("unknown tag naming %s of extra tag %s \n", naming, key)
+			continue
+		}
+		if options != "" {
+			base := ns
+			ns = func(columnName string) string { return base(columnName) + "," + options }
+		}
+		opts = append(opts, gen.FieldNewTagWithNS(key, ns))
+	}
+	return opts
+}
+
+// tagNameStrategy name strategy of tag value by naming
+func tagNameStrategy(naming string) (func(columnName string) string, bool) {
+	switch naming {
+	case TagNamingColumn:
+		return func(columnName string) string { return columnName }, true
+	case JSONTagSnake:
+		return toSnake, true
+	case JSONTagCamel, JSONTagLowerCamel:
+		return toLowerCamel, true
+	case JSONTagPascal:
+		return toPascal, true
+	}
+	return nil, false
+}
+
+// jsonTagField apply json tag overrides and omitempty rule of table
+func (c *CmdParams) jsonTagField(table string, columns map[string]gorm.ColumnType) gen.ModelOpt {
+	var overrides = make(map[string]string)
+	for _, v := range c.JSONTags {
+		offset := strings.Index(v, ":")
+		if offset <= 0 {
+			continue
+		}
+		name, tag := strings.TrimSpace(v[:offset]), strings.TrimSpace(v[offset+1:])
+		if i := strings.LastIndex(name, "."); i > 0 {
+			// schema qualified table, eg: billing.invoices.amount
+			if name[:i] != table {
+				continue
+			}
+			name = name[i+1:]
+		} else if _, ok := overrides[name]; ok {
+			continue // table scoped override first
+		}
+		overrides[name] = tag
+	}
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		if tag, ok := overrides[f.ColumnName]; ok {
+			f.Tag.Set(field.TagKeyJson, tag)
+			return f
+		}
+		tag := f.Tag[field.TagKeyJson]
+		if c.JSONTagNaming == JSONTagNone || tag == "" {
+			f.Tag.Remove(field.TagKeyJson)
+			return f
+		}
+		if tag == "-" || strings.Contains(tag, ","+jsonOmitempty) {
+			return f
+		}
+		switch c.JSONOmitempty {
+		case JSONOmitemptyAll:
+			f.Tag.Set(field.TagKeyJson, tag+","+jsonOmitempty)
+		case JSONOmitemptyNullable:
+			if column, ok := columns[f.ColumnName]; ok {
+				if nullable, _ := column.Nullable(); nullable {
+					f.Tag.Set(field.TagKeyJson, tag+","+jsonOmitempty)
+				}
+			}
+		}
+		return f
+	})
+}
+
+// toSnake userName => user_name
+func toSnake(name string) string {
+	var (
+		runes = []rune(name)
+		buf   strings.Builder
+	)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				buf.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// toPascal user_name => UserName
+func toPascal(name string) string {
+	var buf strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		buf.WriteString(string(runes))
+	}
+	return buf.String()
+}
+
+// toLowerCamel user_name => userName
+func toLowerCamel(name string) string {
+	runes := []rune(toPascal(name))
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// namer gorm naming strategy with custom initialisms
+type namer struct {
+	schema.NamingStrategy
+	initialisms map[string]struct{}
+}
+
+// SchemaName column name to go name, custom initialisms upper case
+func (n namer) SchemaName(name string) string {
+	return n.toName(name, false)
+}
+
+// toName name to go name, singular last word of name when singular is true
+func (n namer) toName(name string, singular bool) string {
+	var (
+		buf   strings.Builder
+		parts = strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' })
+	)
+	for i, part := range parts {
+		if _, ok := n.initialisms[strings.ToUpper(part)]; ok {
+			buf.WriteString(strings.ToUpper(part))
+			continue
+		}
+		ns := schema.NamingStrategy{SingularTable: !singular || i < len(parts)-1}
+		buf.WriteString(ns.SchemaName(part))
+	}
+	if buf.Len() == 0 {
+		return schema.NamingStrategy{SingularTable: !singular}.SchemaName(name)
+	}
+	return buf.String()
+}
+
+// identifierMaxLength max identifier length of database, gorm default 64 when not found
+var identifierMaxLength = map[DBType]int{
+	DbMySQL:     64,
+	DbPostgres:  63,
+	DbSQLServer: 128,
+}
+
+// GetNamingStrategy gorm naming strategy, nil means gorm default.
+// a single table prefix is table prefix of strategy, table of model is prefixed like prefix trimmed from model name,
+// eg: Order => t_orders. strategy with table prefix is namer, gen prefix tables without prefix of gorm NamingStrategy
+func (c *CmdParams) GetNamingStrategy() schema.Namer {
+	ns := schema.NamingStrategy{
+		IdentifierMaxLength: identifierMaxLength[c.GetDBType()],
+		SingularTable:       c.ModelNameSignable,
+	}
+	if ns.IdentifierMaxLength == 0 {
+		ns.IdentifierMaxLength = 64
+	}
+	var prefixes []string
+	for _, v := range c.TablePrefixes {
+		if v = strings.TrimSpace(v); v != "" {
+			prefixes = append(prefixes, v)
+		}
+	}
+	if len(prefixes) == 1 {
+		ns.TablePrefix = prefixes[0]
+	}
+	if len(c.Initialisms) > 0 || ns.TablePrefix != "" {
+		var initialisms = make(map[string]struct{}, len(c.Initialisms))
+		for _, v := range c.Initialisms {
+			if v = strings.ToUpper(strings.TrimSpace(v)); v != "" {
+				initialisms[v] = struct{}{}
+			}
+		}
+		return namer{NamingStrategy: ns, initialisms: initialisms}
+	}
+	if c.ModelNameSignable || ns.IdentifierMaxLength != 64 {
+		return ns
+	}
+	return nil
+}
+
+// GetModelNameStrategy model name of table, nil means gen default
+func (c *CmdParams) GetModelNameStrategy() func(tableName string) string {
+	if len(c.TablePrefixes) == 0 && len(c.ModelNames) == 0 && len(c.Initialisms) == 0 &&
+		c.ModelNamePrefix == "" && c.ModelNameSuffix == "" && !c.IsMultiSchema() {
+		return nil
+	}
+	var (
+		names    = make(map[string]string, len(c.ModelNames))
+		prefixes = make([]string, 0, len(c.TablePrefixes))
+		n, _     = c.GetNamingStrategy().(namer)
+	)
+	for _, v := range c.ModelNames {
+		if table, name, ok := strings.Cut(v, ":"); ok && strings.TrimSpace(table) != "" {
+			names[strings.TrimSpace(table)] = strings.TrimSpace(name)
+		}
+	}
+	for _, v := range c.TablePrefixes {
+		if v = strings.TrimSpace(v); v != "" {
+			prefixes = append(prefixes, v)
+		}
+	}
+	// longest prefix first, eg: tbl_ before t_
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	return func(tableName string) string {
+		if name, ok := names[tableName]; ok && name != "" {
+			return name
+		}
+		schemaName, name := SplitSchemaTable(tableName)
+		if schemaName != "" {
+			if v, ok := names[name]; ok && v != "" && c.GetSchemaLayout() == SchemaLayoutPackage {
+				return v
+			}
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+				name = strings.TrimPrefix(name, prefix)
+				break
+			}
+		}
+		if len(n.initialisms) > 0 {
+			name = n.toName(name, !c.ModelNameSignable)
+		} else {
+			name = schema.NamingStrategy{SingularTable: c.ModelNameSignable}.SchemaName(name)
+		}
+		if schemaName != "" && c.GetSchemaLayout() == SchemaLayoutPrefix {
+			name = toPascal(schemaName) + name
+		}
+		return c.ModelNamePrefix + name + c.ModelNameSuffix
+	}
+}
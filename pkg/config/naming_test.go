@@ -0,0 +1,225 @@
+package config
+
+import (
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestNameCases(t *testing.T) {
+	cases := []struct {
+		name       string
+		snake      string
+		pascal     string
+		lowerCamel string
+	}{
+		{name: "user_name", snake: "user_name", pascal: "UserName", lowerCamel: "userName"},
+		{name: "userName", snake: "user_name", pascal: "UserName", lowerCamel: "userName"},
+		{name: "UserID", snake: "user_id", pascal: "UserID", lowerCamel: "userID"},
+		{name: "HTTPStatus", snake: "http_status", pascal: "HTTPStatus", lowerCamel: "httpStatus"},
+		{name: "id", snake: "id", pascal: "Id", lowerCamel: "id"},
+		{name: "created-at", snake: "created-at", pascal: "CreatedAt", lowerCamel: "createdAt"},
+		{name: "", snake: "", pascal: "", lowerCamel: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := toSnake(c.name); got != c.snake {
+				t.Errorf("toSnake(%q) = %q, want %q", c.name, got, c.snake)
+			}
+			if got := toPascal(c.name); got != c.pascal {
+				t.Errorf("toPascal(%q) = %q, want %q", c.name, got, c.pascal)
+			}
+			if got := toLowerCamel(c.name); got != c.lowerCamel {
+				t.Errorf("toLowerCamel(%q) = %q, want %q", c.name, got, c.lowerCamel)
+			}
+		})
+	}
+}
+
+func TestGetJSONTagNameStrategy(t *testing.T) {
+	cases := []struct {
+		naming string
+		column string
+		want   string
+		isNil  bool
+	}{
+		{naming: "", isNil: true},
+		{naming: "unknown", isNil: true},
+		{naming: JSONTagSnake, column: "userName", want: "user_name"},
+		{naming: JSONTagCamel, column: "user_name", want: "userName"},
+		{naming: JSONTagLowerCamel, column: "user_name", want: "userName"},
+		{naming: JSONTagPascal, column: "user_name", want: "UserName"},
+		{naming: JSONTagNone, column: "user_name", want: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.naming, func(t *testing.T) {
+			strategy := (&CmdParams{JSONTagNaming: c.naming}).GetJSONTagNameStrategy()
+			if c.isNil {
+				if strategy != nil {
+					t.Errorf("strategy of %q must be nil", c.naming)
+				}
+				return
+			}
+			if got := strategy(c.column); got != c.want {
+				t.Errorf("strategy %q of %q = %q, want %q", c.naming, c.column, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTagNameStrategy(t *testing.T) {
+	cases := []struct {
+		naming string
+		want   string
+		ok     bool
+	}{
+		{naming: TagNamingColumn, want: "user_name", ok: true},
+		{naming: JSONTagSnake, want: "user_name", ok: true},
+		{naming: JSONTagCamel, want: "userName", ok: true},
+		{naming: JSONTagPascal, want: "UserName", ok: true},
+		{naming: JSONTagNone, ok: false},
+		{naming: "kebab", ok: false},
+	}
+	for _, c := range cases {
+		t.Run(c.naming, func(t *testing.T) {
+			ns, ok := tagNameStrategy(c.naming)
+			if ok != c.ok {
+				t.Fatalf("tagNameStrategy(%q) ok = %v, want %v", c.naming, ok, c.ok)
+			}
+			if ok && ns("user_name") != c.want {
+				t.Errorf("tagNameStrategy(%q)(user_name) = %q, want %q", c.naming, ns("user_name"), c.want)
+			}
+		})
+	}
+}
+
+func TestExtraTagFields(t *testing.T) {
+	cases := []struct {
+		name string
+		tags []string
+		want int
+	}{
+		{name: "empty", tags: nil, want: 0},
+		{name: "valid", tags: []string{"yaml:snake", "bson:column,omitempty", "xml: camel"}, want: 3},
+		{name: "missing naming", tags: []string{"yaml"}, want: 0},
+		{name: "missing key", tags: []string{":snake"}, want: 0},
+		{name: "unknown naming", tags: []string{"yaml:kebab", "toml:snake"}, want: 1},
+		{name: "none is not a tag naming", tags: []string{"yaml:none"}, want: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := len((&CmdParams{ExtraTags: c.tags}).extraTagFields()); got != c.want {
+				t.Errorf("extraTagFields(%v) = %d options, want %d", c.tags, got, c.want)
+			}
+		})
+	}
+}
+
+func TestNamerInitialisms(t *testing.T) {
+	n := (&CmdParams{Initialisms: []string{"sku", " Api "}}).GetNamingStrategy()
+	cases := []struct {
+		column string
+		want   string
+	}{
+		{column: "user_id", want: "UserID"},
+		{column: "sku_code", want: "SKUCode"},
+		{column: "api_keys", want: "APIKeys"},
+		{column: "http_url", want: "HTTPURL"},
+		{column: "order_items", want: "OrderItems"},
+		{column: "user_skus", want: "UserSkus"},
+	}
+	for _, c := range cases {
+		t.Run(c.column, func(t *testing.T) {
+			if got := n.SchemaName(c.column); got != c.want {
+				t.Errorf("SchemaName(%q) = %q, want %q", c.column, got, c.want)
+			}
+		})
+	}
+	if (&CmdParams{}).GetNamingStrategy() != nil {
+		t.Errorf("naming strategy without options must be gorm default")
+	}
+}
+
+func TestGetModelNameStrategy(t *testing.T) {
+	cases := []struct {
+		name   string
+		params CmdParams
+		table  string
+		want   string
+	}{
+		{name: "initialisms singular", params: CmdParams{Initialisms: []string{"sku", "api"}}, table: "sku_prices", want: "SKUPrice"},
+		{name: "initialisms last word", params: CmdParams{Initialisms: []string{"api"}}, table: "t_api_keys", want: "TAPIKey"},
+		{name: "initialisms signable", params: CmdParams{Initialisms: []string{"api"}, ModelNameSignable: true}, table: "t_api_keys", want: "TAPIKeys"},
+		{name: "longest prefix first", params: CmdParams{TablePrefixes: []string{"t_", "tbl_"}}, table: "tbl_companies", want: "Company"},
+		{name: "prefix", params: CmdParams{TablePrefixes: []string{"t_", "tbl_"}}, table: "t_api_keys", want: "APIKey"},
+		{name: "prefix only name kept", params: CmdParams{TablePrefixes: []string{"t_"}}, table: "t_", want: "T"},
+		{name: "no prefix match", params: CmdParams{TablePrefixes: []string{"t_"}}, table: "order_items", want: "OrderItem"},
+		{name: "prefix and suffix", params: CmdParams{TablePrefixes: []string{"t_"}, ModelNamePrefix: "Db", ModelNameSuffix: "Model"}, table: "t_api_keys", want: "DbAPIKeyModel"},
+		{name: "model name override", params: CmdParams{TablePrefixes: []string{"t_"}, ModelNames: []string{"t_api_keys: Credential"}}, table: "t_api_keys", want: "Credential"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.params.GetModelNameStrategy()(c.table); got != c.want {
+				t.Errorf("model name of %q = %q, want %q", c.table, got, c.want)
+			}
+		})
+	}
+	if (&CmdParams{}).GetModelNameStrategy() != nil {
+		t.Errorf("model name strategy without options must be gen default")
+	}
+}
+
+func TestGetNamingStrategy(t *testing.T) {
+	cases := []struct {
+		name      string
+		params    CmdParams
+		maxLength int
+		prefix    string
+		isNil     bool
+	}{
+		{name: "mysql default", params: CmdParams{DB: "mysql"}, isNil: true},
+		{name: "postgres", params: CmdParams{DB: "postgres"}, maxLength: 63},
+		{name: "sqlserver", params: CmdParams{DB: "sqlserver"}, maxLength: 128},
+		{name: "sqlite", params: CmdParams{DB: "sqlite", ModelNameSignable: true}, maxLength: 64},
+		{name: "one table prefix", params: CmdParams{DB: "mysql", TablePrefixes: []string{" t_ "}}, maxLength: 64, prefix: "t_"},
+		{name: "several table prefixes", params: CmdParams{DB: "postgres", TablePrefixes: []string{"t_", "tbl_"}}, maxLength: 63},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			n := c.params.GetNamingStrategy()
+			if c.isNil {
+				if n != nil {
+					t.Fatalf("GetNamingStrategy() = %+v, want nil", n)
+				}
+				return
+			}
+			var ns schema.NamingStrategy
+			switch v := n.(type) {
+			case schema.NamingStrategy:
+				ns = v
+			case namer:
+				ns = v.NamingStrategy
+			default:
+				t.Fatalf("GetNamingStrategy() = %T, want naming strategy", n)
+			}
+			if ns.IdentifierMaxLength != c.maxLength || ns.TablePrefix != c.prefix {
+				t.Errorf("GetNamingStrategy() max length %d prefix %q, want %d %q", ns.IdentifierMaxLength, ns.TablePrefix, c.maxLength, c.prefix)
+			}
+		})
+	}
+}
+
+func TestNamingStrategyTablePrefix(t *testing.T) {
+	params := CmdParams{DB: "mysql", TablePrefixes: []string{"t_"}}
+	n := params.GetNamingStrategy()
+	if got := n.TableName("Order"); got != "t_orders" {
+		t.Errorf("TableName(Order) = %q, want t_orders", got)
+	}
+	if got := params.GetModelNameStrategy()("t_orders"); got != "Order" {
+		t.Errorf("model name of t_orders = %q, want Order", got)
+	}
+	// column with table prefix keeps prefix
+	if got := n.SchemaName("t_flag"); got != "TFlag" {
+		t.Errorf("SchemaName(t_flag) = %q, want TFlag", got)
+	}
+}
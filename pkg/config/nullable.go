@@ -0,0 +1,160 @@
+package config
+
+import (
+	"log"
+	"strings"
+
+	gen "gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+const (
+	NullableStylePointer = "pointer"
+	NullableStyleSQLNull = "sqlnull"
+	NullableStyleGeneric = "generic"
+	NullableStyleGuregu  = "guregu"
+
+	sqlPkgPath    = "database/sql"
+	gureguPkgPath = "github.com/guregu/null/v5"
+)
+
+var (
+	// sqlNullTypes database/sql null types of go types, other types use pointer
+	sqlNullTypes = map[string]string{
+		"string":    "sql.NullString",
+		"int":       "sql.NullInt64",
+		"int64":     "sql.NullInt64",
+		"uint32":    "sql.NullInt64",
+		"int32":     "sql.NullInt32",
+		"uint16":    "sql.NullInt32",
+		"int16":     "sql.NullInt16",
+		"int8":      "sql.NullInt16",
+		"uint8":     "sql.NullByte",
+		"float64":   "sql.NullFloat64",
+		"float32":   "sql.NullFloat64",
+		"bool":      "sql.NullBool",
+		"time.Time": "sql.NullTime",
+	}
+	// gureguNullTypes guregu/null types of go types, other types use null.Value[T]
+	gureguNullTypes = map[string]string{
+		"string":    "null.String",
+		"int":       "null.Int",
+		"int64":     "null.Int",
+		"uint32":    "null.Int",
+		"int32":     "null.Int32",
+		"uint16":    "null.Int32",
+		"int16":     "null.Int16",
+		"int8":      "null.Int16",
+		"uint8":     "null.Byte",
+		"float64":   "null.Float",
+		"float32":   "null.Float",
+		"bool":      "null.Bool",
+		"time.Time": "null.Time",
+	}
+	// nullValueFields value field and value type of database/sql and guregu/null types
+	nullValueFields = map[string][2]string{
+		"sql.NullString":  {"String", "string"},
+		"sql.NullInt64":   {"Int64", "int64"},
+		"sql.NullInt32":   {"Int32", "int32"},
+		"sql.NullInt16":   {"Int16", "int16"},
+		"sql.NullByte":    {"Byte", "uint8"},
+		"sql.NullFloat64": {"Float64", "float64"},
+		"sql.NullBool":    {"Bool", "bool"},
+		"sql.NullTime":    {"Time", "time.Time"},
+		"null.String":     {"String", "string"},
+		"null.Int":        {"Int64", "int64"},
+		"null.Int32":      {"Int32", "int32"},
+		"null.Int16":      {"Int16", "int16"},
+		"null.Byte":       {"Byte", "uint8"},
+		"null.Float":      {"Float64", "float64"},
+		"null.Bool":       {"Bool", "bool"},
+		"null.Time":       {"Time", "time.Time"},
+	}
+	// nullableKeepTypes types keeping null by themselves, not changed by nullable style
+	nullableKeepTypes = map[string]struct{}{
+		"[]byte":                 {},
+		"json.RawMessage":        {},
+		"gorm.DeletedAt":         {},
+		"soft_delete.DeletedAt":  {},
+		"optimisticlock.Version": {},
+	}
+)
+
+// GetNullableStyle go type style of nullable columns, empty when pointers are decided by fieldNullable
+func (c *CmdParams) GetNullableStyle() string {
+	switch style := strings.ToLower(strings.TrimSpace(c.NullableStyle)); style {
+	case "":
+		return ""
+	case NullableStylePointer, NullableStyleSQLNull, NullableStyleGeneric, NullableStyleGuregu:
+		return style
+	default:
+		log.Printf("skip unknown nullable style %s, input %s \n", c.NullableStyle,
+			strings.Join([]string{NullableStylePointer, NullableStyleSQLNull, NullableStyleGeneric, NullableStyleGuregu}, "|"))
+		return ""
+	}
+}
+
+// NullValue value field and value type of null type generated by nullable style, false when type is not a null type,
+// eg: sql.NullString => String string, null.Value[int64] => V int64
+func NullValue(goType string) (name, typ string, ok bool) {
+	for _, prefix := range []string{"sql.Null[", "null.Value["} {
+		if strings.HasPrefix(goType, prefix) && strings.HasSuffix(goType, "]") {
+			return "V", goType[len(prefix) : len(goType)-1], true
+		}
+	}
+	v, ok := nullValueFields[goType]
+	return v[0], v[1], ok
+}
+
+// nullableField change type of nullable columns by nullable style, query field keeps type of value,
+// eg: sqlnull *string => sql.NullString with field.String
+func nullableField(style string) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		typ := nullableType(style, f)
+		if typ == f.Type {
+			return f
+		}
+		if _, _, ok := NullValue(typ); ok {
+			if genType := f.GenType(); genType != "Field" {
+				f.CustomGenType = genType
+			}
+		}
+		f.Type = typ
+		return f
+	})
+}
+
+// nullableType go type of field by nullable style, eg: sqlnull *string => sql.NullString, guregu *uuid.UUID => null.Value[uuid.UUID]
+func nullableType(style string, f gen.Field) string {
+	if style == "" || f.IsRelation() || !isNullableField(f) {
+		return f.Type
+	}
+	typ := strings.TrimPrefix(f.Type, "*")
+	if _, ok := nullableKeepTypes[typ]; ok {
+		return f.Type
+	}
+	var nullType string
+	switch style {
+	case NullableStyleSQLNull:
+		nullType = sqlNullTypes[typ]
+	case NullableStyleGeneric:
+		nullType = "sql.Null[" + typ + "]"
+	case NullableStyleGuregu:
+		if nullType = gureguNullTypes[typ]; nullType == "" {
+			nullType = "null.Value[" + typ + "]"
+		}
+	}
+	if nullType == "" {
+		return "*" + typ
+	}
+	return nullType
+}
+
+// isNullableField column of field is nullable, gen sets not null tag on not null columns except primary key
+func isNullableField(f gen.Field) bool {
+	if _, ok := f.GORMTag[field.TagKeyGormPrimaryKey]; ok {
+		return false
+	}
+	_, notNull := f.GORMTag[field.TagKeyGormNotNull]
+	return !notNull
+}
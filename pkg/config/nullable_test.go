@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestNullValue(t *testing.T) {
+	cases := []struct {
+		goType string
+		name   string
+		typ    string
+		ok     bool
+	}{
+		{goType: "sql.NullString", name: "String", typ: "string", ok: true},
+		{goType: "sql.NullInt64", name: "Int64", typ: "int64", ok: true},
+		{goType: "sql.NullByte", name: "Byte", typ: "uint8", ok: true},
+		{goType: "sql.NullTime", name: "Time", typ: "time.Time", ok: true},
+		{goType: "sql.Null[int32]", name: "V", typ: "int32", ok: true},
+		{goType: "sql.Null[datatypes.JSON]", name: "V", typ: "datatypes.JSON", ok: true},
+		{goType: "null.String", name: "String", typ: "string", ok: true},
+		{goType: "null.Int", name: "Int64", typ: "int64", ok: true},
+		{goType: "null.Float", name: "Float64", typ: "float64", ok: true},
+		{goType: "null.Value[uuid.UUID]", name: "V", typ: "uuid.UUID", ok: true},
+		{goType: "string"},
+		{goType: "*string"},
+		{goType: "gorm.DeletedAt"},
+	}
+	for _, c := range cases {
+		t.Run(c.goType, func(t *testing.T) {
+			name, typ, ok := NullValue(c.goType)
+			if name != c.name || typ != c.typ || ok != c.ok {
+				t.Errorf("NullValue(%s) = %q, %q, %v, want %q, %q, %v", c.goType, name, typ, ok, c.name, c.typ, c.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// PackageGroup tables generated into one query and model package
+type PackageGroup struct {
+	Name         string   `yaml:"name"`         // group name, default package dir name
+	Prefixes     []string `yaml:"prefixes"`     // tables with prefix belong to group, eg: billing_
+	Tables       []string `yaml:"tables"`       // tables belong to group
+	OutPath      string   `yaml:"outPath"`      // query code path of group, default: {outPath dir}/{name}/{outPath base}
+	OutFile      string   `yaml:"outFile"`      // query code file name of group, default: file name of outFile
+	ModelPkgName string   `yaml:"modelPkgName"` // model package of group, default: modelPkgName
+}
+
+// GetPackageGroups configured groups and groups of packagePrefixes
+func (c *CmdParams) GetPackageGroups() []PackageGroup {
+	var groups = make([]PackageGroup, 0, len(c.PackageGroups)+len(c.PackagePrefixes))
+	for _, group := range c.PackageGroups {
+		if group.Name == "" && len(group.Prefixes) > 0 {
+			group.Name = strings.Trim(group.Prefixes[0], "_")
+		}
+		if group.Name == "" {
+			continue
+		}
+		groups = append(groups, group)
+	}
+	groups = append(groups, c.schemaPackageGroups()...)
+	for _, prefix := range c.PackagePrefixes {
+		if prefix = strings.TrimSpace(prefix); prefix == "" {
+			continue
+		}
+		groups = append(groups, PackageGroup{
+			Name:     strings.Trim(prefix, "_"),
+			Prefixes: []string{prefix},
+		})
+	}
+	return groups
+}
+
+// SplitPackages split tables into package groups, tables out of any group belong to default group with empty name.
+// nil means no package groups configured
+func (c *CmdParams) SplitPackages(tables []string) []PackageGroup {
+	groups := c.GetPackageGroups()
+	if len(groups) == 0 {
+		return nil
+	}
+	var (
+		values   = make([]PackageGroup, len(groups))
+		defaults = PackageGroup{OutPath: c.OutPath, OutFile: c.OutFile, ModelPkgName: c.ModelPkgName}
+	)
+	for i, group := range groups {
+		if group.OutPath == "" {
+			group.OutPath = filepath.Join(filepath.Dir(c.OutPath), group.Name, filepath.Base(c.OutPath))
+		}
+		if group.OutFile == "" && c.OutFile != "" {
+			// outFile with directory is one file of all groups, query code of group written into group outPath
+			group.OutFile = filepath.Base(c.OutFile)
+		}
+		if group.ModelPkgName == "" {
+			group.ModelPkgName = c.ModelPkgName
+		}
+		group.Tables = nil
+		values[i] = group
+	}
+	for _, table := range tables {
+		if i := matchPackageGroup(groups, table); i >= 0 {
+			values[i].Tables = append(values[i].Tables, table)
+		} else {
+			defaults.Tables = append(defaults.Tables, table)
+		}
+	}
+	var result = make([]PackageGroup, 0, len(values)+1)
+	for _, group := range append(values, defaults) {
+		if len(group.Tables) > 0 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// matchPackageGroup index of group the table belong to, table list first then the longest prefix
+func matchPackageGroup(groups []PackageGroup, table string) int {
+	var index, size = -1, 0
+	for i, group := range groups {
+		for _, t := range group.Tables {
+			if t == table {
+				return i
+			}
+		}
+		for _, prefix := range group.Prefixes {
+			if strings.HasPrefix(table, prefix) && len(prefix) > size {
+				index, size = i, len(prefix)
+			}
+		}
+	}
+	return index
+}
+
+// GetProtoOutPath protobuf output directory of query code path
+func (c *CmdParams) GetProtoOutPath(outPath string) string {
+	if c.ProtoOutPath != "" {
+		return c.ProtoOutPath
+	}
+	return filepath.Join(filepath.Dir(outPath), "proto")
+}
+
+// GetOpenAPIOutPath json schema and openapi output directory of query code path
+func (c *CmdParams) GetOpenAPIOutPath(outPath string) string {
+	if c.OpenAPIOutPath != "" {
+		return c.OpenAPIOutPath
+	}
+	return filepath.Join(filepath.Dir(outPath), "openapi")
+}
+
+// GetTypeScriptOutPath typescript output directory of query code path
+func (c *CmdParams) GetTypeScriptOutPath(outPath string) string {
+	if c.TypeScriptOutPath != "" {
+		return c.TypeScriptOutPath
+	}
+	return filepath.Join(filepath.Dir(outPath), "types")
+}
+
+// GetGraphQLOutPath graphql output directory of query code path
+func (c *CmdParams) GetGraphQLOutPath(outPath string) string {
+	if c.GraphQLOutPath != "" {
+		return c.GraphQLOutPath
+	}
+	return filepath.Join(filepath.Dir(outPath), "graph")
+}
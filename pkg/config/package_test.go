@@ -0,0 +1,40 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitPackagesOutFile(t *testing.T) {
+	cases := []struct {
+		name    string
+		outFile string
+		group   string // outFile of billing group
+		want    map[string]string
+	}{
+		{name: "file name", outFile: "query.go", want: map[string]string{"billing": "query.go", "": "query.go"}},
+		{name: "file with directory", outFile: filepath.Join("dao", "query", "query.go"),
+			want: map[string]string{"billing": "query.go", "": filepath.Join("dao", "query", "query.go")}},
+		{name: "group file", outFile: filepath.Join("dao", "query", "query.go"), group: "billing.go",
+			want: map[string]string{"billing": "billing.go", "": filepath.Join("dao", "query", "query.go")}},
+		{name: "no file", want: map[string]string{"billing": "", "": ""}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			params := CmdParams{
+				OutPath:       filepath.Join("dao", "query"),
+				OutFile:       c.outFile,
+				PackageGroups: []PackageGroup{{Name: "billing", Prefixes: []string{"billing_"}, OutFile: c.group}},
+			}
+			groups := params.SplitPackages([]string{"billing_orders", "users"})
+			if len(groups) != len(c.want) {
+				t.Fatalf("SplitPackages() = %+v, want %d groups", groups, len(c.want))
+			}
+			for _, group := range groups {
+				if want := c.want[group.Name]; group.OutFile != want {
+					t.Errorf("SplitPackages() group %q outFile = %q, want %q", group.Name, group.OutFile, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import "strings"
+
+const (
+	PostgresKindArray    = "array"
+	PostgresKindRange    = "range"
+	PostgresKindHstore   = "hstore"
+	PostgresKindGeometry = "geometry"
+)
+
+// PostgresRange range type generated into model package, pgtype.Range has no sql.Scanner
+type PostgresRange struct {
+	DBType  string // database type name, eg: tstzrange
+	Name    string // generated go type, eg: PgTstzRange
+	Element string // pgtype element type, eg: pgtype.Timestamptz
+	OID     string // pgtype oid constant, eg: pgtype.TstzrangeOID
+	SubType string // database type of element, eg: timestamptz
+}
+
+// PostgresRanges range types of postgres
+var PostgresRanges = []PostgresRange{
+	{DBType: "int4range", Name: "PgInt4Range", Element: "pgtype.Int4", OID: "pgtype.Int4rangeOID", SubType: "integer"},
+	{DBType: "int8range", Name: "PgInt8Range", Element: "pgtype.Int8", OID: "pgtype.Int8rangeOID", SubType: "bigint"},
+	{DBType: "numrange", Name: "PgNumRange", Element: "pgtype.Numeric", OID: "pgtype.NumrangeOID", SubType: "numeric"},
+	{DBType: "tsrange", Name: "PgTsRange", Element: "pgtype.Timestamp", OID: "pgtype.TsrangeOID", SubType: "timestamp"},
+	{DBType: "tstzrange", Name: "PgTstzRange", Element: "pgtype.Timestamptz", OID: "pgtype.TstzrangeOID", SubType: "timestamptz"},
+	{DBType: "daterange", Name: "PgDateRange", Element: "pgtype.Date", OID: "pgtype.DaterangeOID", SubType: "date"},
+}
+
+// PostgresNetTypes network types of postgres with go type generated into model package,
+// netip.Addr, netip.Prefix and net.HardwareAddr have no sql.Scanner
+var PostgresNetTypes = map[string]string{
+	"inet":    "PgInet",
+	"cidr":    "PgCidr",
+	"macaddr": "PgMacaddr",
+}
+
+// postgresGeometryTypes geometric types of postgres with pgtype go type
+var postgresGeometryTypes = map[string]string{
+	"point":   "pgtype.Point",
+	"line":    "pgtype.Line",
+	"lseg":    "pgtype.Lseg",
+	"box":     "pgtype.Box",
+	"path":    "pgtype.Path",
+	"polygon": "pgtype.Polygon",
+	"circle":  "pgtype.Circle",
+}
+
+// postgresArrayMappings array types of postgres mapped to lib/pq arrays
+var postgresArrayMappings = []TypeMapping{
+	{DBType: "text[]", Type: "pq.StringArray"},
+	{DBType: "character varying[]", Type: "pq.StringArray"},
+	{DBType: "uuid[]", Type: "pq.StringArray"},
+	{DBType: "smallint[]", Type: "pq.Int32Array"},
+	{DBType: "integer[]", Type: "pq.Int32Array"},
+	{DBType: "bigint[]", Type: "pq.Int64Array"},
+	{DBType: "real[]", Type: "pq.Float32Array"},
+	{DBType: "double precision[]", Type: "pq.Float64Array"},
+	{DBType: "numeric[]", Type: "pq.Float64Array"},
+	{DBType: "boolean[]", Type: "pq.BoolArray"},
+	{DBType: "bytea[]", Type: "pq.ByteaArray"},
+}
+
+// postgresTypeMappings default mappings of postgres array, hstore, range and geometric types,
+// applied after configured mappings so these columns are not generated as string
+func postgresTypeMappings() []TypeMapping {
+	var mappings = make([]TypeMapping, 0, len(postgresArrayMappings)+len(PostgresRanges)+len(postgresGeometryTypes)+1)
+	mappings = append(mappings, postgresArrayMappings...)
+	mappings = append(mappings, TypeMapping{DBType: "hstore", Type: "pgtype.Hstore"})
+	for _, r := range PostgresRanges {
+		mappings = append(mappings, TypeMapping{DBType: r.DBType, Type: r.Name})
+	}
+	for _, name := range []string{"point", "line", "lseg", "box", "path", "polygon", "circle"} {
+		mappings = append(mappings, TypeMapping{DBType: name, Type: postgresGeometryTypes[name]})
+	}
+	return mappings
+}
+
+// PostgresTypeKind kind of postgres column type with query operators, empty for other types,
+// eg: text[] => array, tstzrange => range
+func PostgresTypeKind(typeName string) string {
+	typeName = baseTypeName(typeName)
+	switch {
+	case strings.HasSuffix(typeName, "[]") || strings.HasPrefix(typeName, "_"):
+		return PostgresKindArray
+	case typeName == "hstore":
+		return PostgresKindHstore
+	}
+	if _, ok := postgresGeometryTypes[typeName]; ok {
+		return PostgresKindGeometry
+	}
+	for _, r := range PostgresRanges {
+		if r.DBType == typeName {
+			return PostgresKindRange
+		}
+	}
+	return ""
+}
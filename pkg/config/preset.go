@@ -0,0 +1,121 @@
+package config
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	TypePresetPostgresRich   = "pg-rich"
+	TypePresetMySQLRich      = "mysql-rich"
+	TypePresetClickHouseRich = "clickhouse-rich"
+
+	datatypesPkgPath = "gorm.io/datatypes"
+	uuidPkgPath      = "github.com/google/uuid"
+	decimalPkgPath   = "github.com/shopspring/decimal"
+	pqPkgPath        = "github.com/lib/pq"
+	pgtypePkgPath    = "github.com/jackc/pgx/v5/pgtype"
+)
+
+// typePreset type mappings of dialect with import paths of mapped types
+type typePreset struct {
+	Mappings    []TypeMapping
+	ImportPaths []string
+}
+
+// builtinTypePresets built-in type mappings selected by typePreset
+var builtinTypePresets = map[string]typePreset{
+	TypePresetPostgresRich: {
+		Mappings: []TypeMapping{
+			{DBType: "int2", Type: "int16"},
+			{DBType: "int4", Type: "int32"},
+			{DBType: "int8", Type: "int64"},
+			{DBType: "float4", Type: "float32"},
+			{DBType: "float8", Type: "float64"},
+			{DBType: "bool", Type: "bool"},
+			{DBType: "bytea", Type: "[]byte"},
+			{DBType: "timestamptz", Type: "time.Time"},
+			{DBType: "timestamp", Type: "time.Time"},
+			{DBType: "date", Type: "time.Time"},
+			{DBType: "json", Type: "datatypes.JSON"},
+			{DBType: "jsonb", Type: "datatypes.JSON"},
+			{DBType: "uuid", Type: "uuid.UUID"},
+			{DBType: "numeric", Type: "decimal.Decimal"},
+			{DBType: "money", Type: "string"},
+			{DBType: "inet", Type: PostgresNetTypes["inet"]},
+			{DBType: "cidr", Type: PostgresNetTypes["cidr"]},
+			{DBType: "macaddr", Type: PostgresNetTypes["macaddr"]},
+			{DBType: "interval", Type: "pgtype.Interval"},
+		},
+		ImportPaths: []string{datatypesPkgPath, uuidPkgPath, decimalPkgPath, pqPkgPath, pgtypePkgPath},
+	},
+	TypePresetMySQLRich: {
+		Mappings: []TypeMapping{
+			{ColumnType: "tinyint(1)", Type: "bool"},
+			{DBType: "decimal", Type: "decimal.Decimal"},
+			{DBType: "json", Type: "datatypes.JSON"},
+			{ColumnType: "binary(16)", Column: "*uuid", Type: "datatypes.BinUUID"},
+			{ColumnType: "char(36)", Column: "*uuid", Type: "uuid.UUID"},
+		},
+		ImportPaths: []string{datatypesPkgPath, uuidPkgPath, decimalPkgPath},
+	},
+	TypePresetClickHouseRich: {
+		// Nullable(T) and LowCardinality(T) match database type T
+		Mappings: []TypeMapping{
+			{DBType: "string", Type: "string"},
+			{DBType: "fixedstring", Type: "string"},
+			{DBType: "int8", Type: "int8"},
+			{DBType: "int16", Type: "int16"},
+			{DBType: "int32", Type: "int32"},
+			{DBType: "int64", Type: "int64"},
+			{DBType: "uint8", Type: "uint8"},
+			{DBType: "uint16", Type: "uint16"},
+			{DBType: "uint32", Type: "uint32"},
+			{DBType: "uint64", Type: "uint64"},
+			{DBType: "float32", Type: "float32"},
+			{DBType: "float64", Type: "float64"},
+			{DBType: "bool", Type: "bool"},
+			{DBType: "date", Type: "time.Time"},
+			{DBType: "date32", Type: "time.Time"},
+			{DBType: "datetime", Type: "time.Time"},
+			{DBType: "datetime64", Type: "time.Time"},
+			{DBType: "uuid", Type: "uuid.UUID"},
+			{DBType: "decimal", Type: "decimal.Decimal"},
+			{ColumnType: "array(string)", Type: "[]string"},
+			{ColumnType: "array(lowcardinality(string))", Type: "[]string"},
+			{ColumnType: "array(int32)", Type: "[]int32"},
+			{ColumnType: "array(int64)", Type: "[]int64"},
+			{ColumnType: "array(uint64)", Type: "[]uint64"},
+			{ColumnType: "array(float64)", Type: "[]float64"},
+		},
+		ImportPaths: []string{uuidPkgPath, decimalPkgPath},
+	},
+}
+
+// TypePresetNames names of built-in type presets
+func TypePresetNames() []string {
+	var names = make([]string, 0, len(builtinTypePresets))
+	for name := range builtinTypePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typePresets selected type presets of typePreset, eg: pg-rich or pg-rich,clickhouse-rich, unknown presets are skipped
+func (c *CmdParams) typePresets() []typePreset {
+	var presets []typePreset
+	for _, name := range strings.Split(c.TypePreset, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name == "" {
+			continue
+		}
+		preset, ok := builtinTypePresets[name]
+		if !ok {
+			log.Printf("skip unknown type preset %s, input %s \n", name, strings.Join(TypePresetNames(), "|"))
+			continue
+		}
+		presets = append(presets, preset)
+	}
+	return presets
+}
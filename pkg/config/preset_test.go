@@ -0,0 +1,110 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBaseTypeName(t *testing.T) {
+	cases := []struct {
+		typeName string
+		want     string
+	}{
+		{typeName: "int4", want: "int4"},
+		{typeName: "VARCHAR(64)", want: "varchar"},
+		{typeName: "decimal(20,4)", want: "decimal"},
+		{typeName: "character varying(64)[]", want: "character varying[]"},
+		{typeName: "Nullable(DateTime64(3))", want: "datetime64"},
+		{typeName: "LowCardinality(Nullable(String))", want: "string"},
+		{typeName: "Nullable(Decimal(18, 4))", want: "decimal"},
+		{typeName: "Array(String)", want: "array"},
+		{typeName: " timestamptz ", want: "timestamptz"},
+	}
+	for _, c := range cases {
+		t.Run(c.typeName, func(t *testing.T) {
+			if got := baseTypeName(c.typeName); got != c.want {
+				t.Errorf("baseTypeName(%q) = %q, want %q", c.typeName, got, c.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinTypePresetsValid(t *testing.T) {
+	for name, preset := range builtinTypePresets {
+		for _, m := range preset.Mappings {
+			if err := m.Validate(); err != nil {
+				t.Errorf("preset %s: %v", name, err)
+			}
+		}
+	}
+}
+
+func TestTypePresets(t *testing.T) {
+	cases := []struct {
+		preset string
+		want   int
+	}{
+		{preset: "", want: 0},
+		{preset: TypePresetPostgresRich, want: 1},
+		{preset: " PG-RICH , clickhouse-rich", want: 2},
+		{preset: "pg-rich,unknown", want: 1},
+		{preset: ",,", want: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.preset, func(t *testing.T) {
+			if got := len((&CmdParams{TypePreset: c.preset}).typePresets()); got != c.want {
+				t.Errorf("typePresets(%q) = %d presets, want %d", c.preset, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTypePresetColumnType(t *testing.T) {
+	cases := []struct {
+		name   string
+		params CmdParams
+		column string
+		dbType string
+		typ    string
+		want   string
+		ok     bool
+	}{
+		{name: "pg int4", params: CmdParams{TypePreset: TypePresetPostgresRich}, column: "id", dbType: "int4", typ: "integer", want: "int32", ok: true},
+		{name: "pg jsonb", params: CmdParams{TypePreset: TypePresetPostgresRich}, column: "data", dbType: "jsonb", typ: "jsonb", want: "datatypes.JSON", ok: true},
+		{name: "mysql tinyint(1) unsigned", params: CmdParams{TypePreset: TypePresetMySQLRich}, column: "ok", dbType: "tinyint", typ: "tinyint(1) unsigned", want: "bool", ok: true},
+		{name: "mysql uuid by column name", params: CmdParams{TypePreset: TypePresetMySQLRich}, column: "order_uuid", dbType: "char", typ: "char(36)", want: "uuid.UUID", ok: true},
+		{name: "pg inet", params: CmdParams{TypePreset: TypePresetPostgresRich}, column: "ip", dbType: "inet", typ: "inet", want: "PgInet", ok: true},
+		{name: "pg macaddr", params: CmdParams{TypePreset: TypePresetPostgresRich}, column: "mac", dbType: "macaddr", typ: "macaddr", want: "PgMacaddr", ok: true},
+		{name: "mysql binary uuid", params: CmdParams{TypePreset: TypePresetMySQLRich}, column: "user_uuid", dbType: "binary", typ: "binary(16)", want: "datatypes.BinUUID", ok: true},
+		{name: "mysql char without uuid name", params: CmdParams{TypePreset: TypePresetMySQLRich}, column: "code", dbType: "char", typ: "char(36)", ok: false},
+		{name: "clickhouse nullable datetime64", params: CmdParams{TypePreset: TypePresetClickHouseRich}, column: "at", dbType: "Nullable(DateTime64(3))", typ: "Nullable(DateTime64(3))", want: "time.Time", ok: true},
+		{name: "clickhouse low cardinality", params: CmdParams{TypePreset: TypePresetClickHouseRich}, column: "kind", dbType: "LowCardinality(String)", typ: "LowCardinality(String)", want: "string", ok: true},
+		{name: "configured mapping first", params: CmdParams{TypePreset: TypePresetPostgresRich, FieldsTypeMapping: []string{"int4:int64"}}, column: "id", dbType: "int4", typ: "integer", want: "int64", ok: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := c.params.GetColumnType("t", newColumn(c.column, c.dbType, c.typ, false))
+			if got != c.want || ok != c.ok {
+				t.Errorf("GetColumnType(%s %s) = %q, %v, want %q, %v", c.column, c.typ, got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
+
+func TestTypePresetImportPaths(t *testing.T) {
+	paths := (&CmdParams{TypePreset: "pg-rich,mysql-rich"}).GetImportPkgPaths()
+	for _, v := range []string{datatypesPkgPath, uuidPkgPath, decimalPkgPath} {
+		var count int
+		for _, p := range paths {
+			if p == v {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("import path %s added %d times, want 1", v, count)
+		}
+	}
+	if slices.Contains((&CmdParams{}).GetImportPkgPaths(), uuidPkgPath) {
+		t.Errorf("import path %s added without preset", uuidPkgPath)
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	SchemaLayoutPackage = "package" // one package per schema
+	SchemaLayoutPrefix  = "prefix"  // schema name prefixed model name in one package
+)
+
+// IsMultiSchema introspect tables of several schemas, postgres and sqlserver only
+func (c *CmdParams) IsMultiSchema() bool {
+	if len(c.GetSchemas()) == 0 {
+		return false
+	}
+	t := c.GetDBType()
+	return t == DbPostgres || t == DbSQLServer
+}
+
+// GetSchemas configured schemas without blank and duplicate
+func (c *CmdParams) GetSchemas() []string {
+	var schemas = make([]string, 0, len(c.Schemas))
+	for _, v := range c.Schemas {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(schemas, v) {
+			schemas = append(schemas, v)
+		}
+	}
+	return schemas
+}
+
+// GetSchemaLayout layout of schema tables (input package|prefix), default package
+func (c *CmdParams) GetSchemaLayout() string {
+	if strings.EqualFold(c.SchemaLayout, SchemaLayoutPrefix) {
+		return SchemaLayoutPrefix
+	}
+	return SchemaLayoutPackage
+}
+
+// GetSchemaFileNameStrategy file name of schema qualified table, eg: billing.invoices => billing_invoices
+func (c *CmdParams) GetSchemaFileNameStrategy() func(tableName string) string {
+	if !c.IsMultiSchema() {
+		return nil
+	}
+	return func(tableName string) string {
+		return strings.ToLower(strings.ReplaceAll(tableName, ".", "_"))
+	}
+}
+
+// schemaPackageGroups one package group per schema
+func (c *CmdParams) schemaPackageGroups() []PackageGroup {
+	if !c.IsMultiSchema() || c.GetSchemaLayout() != SchemaLayoutPackage {
+		return nil
+	}
+	var groups []PackageGroup
+	for _, v := range c.GetSchemas() {
+		groups = append(groups, PackageGroup{
+			Name:     strings.ToLower(v),
+			Prefixes: []string{SchemaTableName(v, "")},
+		})
+	}
+	return groups
+}
+
+// SchemaTableName schema qualified table name, eg: billing.invoices
+func SchemaTableName(schema, table string) string {
+	if schema == "" {
+		return table
+	}
+	return schema + "." + table
+}
+
+// SplitSchemaTable split schema qualified table name, schema is empty when not qualified
+func SplitSchemaTable(name string) (schema, table string) {
+	if i := strings.LastIndex(name, "."); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	gen "gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// TypeMapping go type of columns matched by database type, column type, column name, nullability and table,
+// conditions not set match any column
+type TypeMapping struct {
+	DBType     string `yaml:"dbType,omitempty"`     // database type name, eg: jsonb, tinyint
+	ColumnType string `yaml:"columnType,omitempty"` // full column type, eg: tinyint(1), decimal(20,4)
+	Column     string `yaml:"column,omitempty"`     // column name pattern, eg: is_*, *_uuid
+	Table      string `yaml:"table,omitempty"`      // table name pattern, eg: orders, log_*
+	Nullable   *bool  `yaml:"nullable,omitempty"`   // only nullable (true) or not null (false) columns
+	Type       string `yaml:"type"`                 // go type, eg: bool, datatypes.JSON
+}
+
+// ParseTypeMapping parse fieldsTypeMapping rule name:type, name with ( is column type otherwise database type,
+// eg: jsonb:datatypes.JSON, tinyint(1):bool
+func ParseTypeMapping(v string) (TypeMapping, error) {
+	name, typ, ok := strings.Cut(v, ":")
+	name, typ = strings.TrimSpace(name), strings.TrimSpace(typ)
+	if !ok || name == "" || typ == "" {
+		return TypeMapping{}, fmt.Errorf("invalid type mapping %q, eg: jsonb:datatypes.JSON", v)
+	}
+	if strings.Contains(name, "(") {
+		return TypeMapping{ColumnType: name, Type: typ}, nil
+	}
+	return TypeMapping{DBType: name, Type: typ}, nil
+}
+
+// Validate type mapping has go type and matches columns by database type, column type or column name
+func (m TypeMapping) Validate() error {
+	if m.Type == "" {
+		return fmt.Errorf("type mapping %s without type", m)
+	}
+	if m.DBType == "" && m.ColumnType == "" && m.Column == "" {
+		return fmt.Errorf("type mapping %s requires dbType, columnType or column", m)
+	}
+	for _, pattern := range []string{m.Column, m.Table} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("type mapping %s with invalid pattern %q: %w", m, pattern, err)
+		}
+	}
+	return nil
+}
+
+// String eg: orders.*_amount decimal(20,4) => decimal.Decimal
+func (m TypeMapping) String() string {
+	var conditions []string
+	if m.Table != "" || m.Column != "" {
+		conditions = append(conditions, strings.TrimPrefix(m.Table+"."+m.Column, "."))
+	}
+	for _, v := range []string{m.DBType, m.ColumnType} {
+		if v != "" {
+			conditions = append(conditions, v)
+		}
+	}
+	if m.Nullable != nil {
+		conditions = append(conditions, fmt.Sprintf("nullable=%v", *m.Nullable))
+	}
+	return strings.Join(conditions, " ") + " => " + m.Type
+}
+
+// Match whether column of table is matched by all conditions of mapping
+func (m TypeMapping) Match(table string, column gorm.ColumnType) bool {
+	if typeName := column.DatabaseTypeName(); m.DBType != "" &&
+		!strings.EqualFold(m.DBType, typeName) && !strings.EqualFold(m.DBType, baseTypeName(typeName)) {
+		return false
+	}
+	if m.ColumnType != "" {
+		columnType, _ := column.ColumnType()
+		columnType, ruleType := normalizeColumnType(columnType), normalizeColumnType(m.ColumnType)
+		// tinyint(1) matches tinyint(1) unsigned
+		if columnType != ruleType && !strings.HasPrefix(columnType, ruleType+" ") {
+			return false
+		}
+	}
+	if m.Column != "" && !matchPattern(m.Column, column.Name()) {
+		return false
+	}
+	if m.Table != "" && !matchPattern(m.Table, table) {
+		// schema qualified table matches bare table pattern
+		if offset := strings.LastIndex(table, "."); offset < 0 || !matchPattern(m.Table, table[offset+1:]) {
+			return false
+		}
+	}
+	if m.Nullable != nil {
+		if nullable, ok := column.Nullable(); ok && nullable != *m.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
+// GetTypeMappings type mappings of typeMappings, fieldsTypeMapping, fieldJSONTypeTag, typePreset and postgres types in order,
+// invalid mappings are skipped
+func (c *CmdParams) GetTypeMappings() []TypeMapping {
+	if c.typeMappings != nil {
+		return c.typeMappings
+	}
+	var mappings = make([]TypeMapping, 0, len(c.TypeMappings)+len(c.FieldsTypeMapping)+1)
+	for _, m := range c.TypeMappings {
+		if err := m.Validate(); err != nil {
+			log.Printf("skip %s \n", err.Error())
+			continue
+		}
+		mappings = append(mappings, m)
+	}
+	for _, v := range c.FieldsTypeMapping {
+		m, err := ParseTypeMapping(v)
+		if err != nil {
+			log.Printf("skip %s \n", err.Error())
+			continue
+		}
+		mappings = append(mappings, m)
+	}
+	if c.FieldJSONTypeTag {
+		mappings = append(mappings, TypeMapping{DBType: "jsonb", Type: "datatypes.JSON"})
+	}
+	for _, preset := range c.typePresets() {
+		mappings = append(mappings, preset.Mappings...)
+	}
+	if c.GetDBType() == DbPostgres {
+		mappings = append(mappings, postgresTypeMappings()...)
+	}
+	c.typeMappings = mappings
+	return mappings
+}
+
+// GetColumnType go type of first type mapping matching column of table, false when column falls back to gen default type
+func (c *CmdParams) GetColumnType(table string, column gorm.ColumnType) (string, bool) {
+	for _, m := range c.GetTypeMappings() {
+		if m.Match(table, column) {
+			return m.Type, true
+		}
+	}
+	return "", false
+}
+
+// typeMappingField replace type of mapped columns, pointer of nullable or coverable field is kept
+func typeMappingField(types map[string]string) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		typ, ok := types[f.ColumnName]
+		if !ok {
+			return f
+		}
+		if strings.HasPrefix(f.Type, "*") && !strings.HasPrefix(typ, "*") {
+			typ = "*" + typ
+		}
+		f.Type = typ
+		return f
+	})
+}
+
+// baseTypeName database type without Nullable, LowCardinality wrapper and length or precision,
+// eg: character varying(64)[] => character varying[], Nullable(DateTime64(3)) => datetime64
+func baseTypeName(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	for _, wrapper := range []string{"nullable(", "lowcardinality("} {
+		if strings.HasPrefix(v, wrapper) && strings.HasSuffix(v, ")") {
+			return baseTypeName(v[len(wrapper) : len(v)-1])
+		}
+	}
+	if start := strings.Index(v, "("); start > 0 {
+		if end := strings.Index(v[start:], ")"); end > 0 {
+			v = strings.TrimSpace(v[:start]) + v[start+end+1:]
+		}
+	}
+	return v
+}
+
+// normalizeColumnType lower column type without spaces in parentheses, eg: DECIMAL(20, 4) => decimal(20,4)
+func normalizeColumnType(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	return strings.NewReplacer(", ", ",", "( ", "(", " )", ")").Replace(v)
+}
+
+// matchPattern case-insensitive glob match of name, eg: is_* matches is_active
+func matchPattern(pattern, name string) bool {
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"database/sql"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// newColumn column of table for type mapping tests
+func newColumn(name, dbType, columnType string, nullable bool) gorm.ColumnType {
+	return migrator.ColumnType{
+		NameValue:       sql.NullString{String: name, Valid: true},
+		DataTypeValue:   sql.NullString{String: dbType, Valid: true},
+		ColumnTypeValue: sql.NullString{String: columnType, Valid: true},
+		NullableValue:   sql.NullBool{Bool: nullable, Valid: true},
+	}
+}
+
+func TestParseTypeMapping(t *testing.T) {
+	cases := []struct {
+		value string
+		want  TypeMapping
+		err   bool
+	}{
+		{value: "jsonb:datatypes.JSON", want: TypeMapping{DBType: "jsonb", Type: "datatypes.JSON"}},
+		{value: " tinyint(1) : bool ", want: TypeMapping{ColumnType: "tinyint(1)", Type: "bool"}},
+		{value: "decimal(20,4):decimal.Decimal", want: TypeMapping{ColumnType: "decimal(20,4)", Type: "decimal.Decimal"}},
+		{value: "jsonb", err: true},
+		{value: ":bool", err: true},
+		{value: "jsonb:", err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			got, err := ParseTypeMapping(c.value)
+			if (err != nil) != c.err {
+				t.Fatalf("ParseTypeMapping(%q) error = %v, want error %v", c.value, err, c.err)
+			}
+			if got != c.want {
+				t.Errorf("ParseTypeMapping(%q) = %+v, want %+v", c.value, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTypeMappingValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		mapping TypeMapping
+		err     bool
+	}{
+		{name: "db type", mapping: TypeMapping{DBType: "jsonb", Type: "datatypes.JSON"}},
+		{name: "column only", mapping: TypeMapping{Column: "is_*", Type: "bool"}},
+		{name: "without type", mapping: TypeMapping{DBType: "jsonb"}, err: true},
+		{name: "table only", mapping: TypeMapping{Table: "orders", Type: "string"}, err: true},
+		{name: "invalid column pattern", mapping: TypeMapping{Column: "is_[", Type: "bool"}, err: true},
+		{name: "invalid table pattern", mapping: TypeMapping{DBType: "int", Table: "[", Type: "int"}, err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.mapping.Validate(); (err != nil) != c.err {
+				t.Errorf("Validate() error = %v, want error %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestTypeMappingMatch(t *testing.T) {
+	var (
+		yes = true
+		no  = false
+	)
+	cases := []struct {
+		name    string
+		mapping TypeMapping
+		table   string
+		column  gorm.ColumnType
+		want    bool
+	}{
+		{name: "db type case-insensitive", mapping: TypeMapping{DBType: "JSONB"}, column: newColumn("data", "jsonb", "jsonb", false), want: true},
+		{name: "db type differs", mapping: TypeMapping{DBType: "json"}, column: newColumn("data", "jsonb", "jsonb", false), want: false},
+		{name: "column type", mapping: TypeMapping{ColumnType: "tinyint(1)"}, column: newColumn("ok", "tinyint", "tinyint(1)", false), want: true},
+		{name: "column type unsigned", mapping: TypeMapping{ColumnType: "tinyint(1)"}, column: newColumn("ok", "tinyint", "tinyint(1) unsigned", false), want: true},
+		{name: "column type width differs", mapping: TypeMapping{ColumnType: "tinyint(1)"}, column: newColumn("ok", "tinyint", "tinyint(10)", false), want: false},
+		{name: "column type spaces", mapping: TypeMapping{ColumnType: "decimal(20,4)"}, column: newColumn("amount", "decimal", "DECIMAL(20, 4)", false), want: true},
+		{name: "column pattern", mapping: TypeMapping{Column: "is_*"}, column: newColumn("IS_ACTIVE", "tinyint", "tinyint(1)", false), want: true},
+		{name: "column pattern differs", mapping: TypeMapping{Column: "is_*"}, column: newColumn("active", "tinyint", "tinyint(1)", false), want: false},
+		{name: "table pattern", mapping: TypeMapping{Table: "log_*", DBType: "text"}, table: "log_2024", column: newColumn("body", "text", "text", false), want: true},
+		{name: "schema qualified table", mapping: TypeMapping{Table: "orders", DBType: "text"}, table: "billing.orders", column: newColumn("body", "text", "text", false), want: true},
+		{name: "table differs", mapping: TypeMapping{Table: "orders", DBType: "text"}, table: "users", column: newColumn("body", "text", "text", false), want: false},
+		{name: "nullable", mapping: TypeMapping{DBType: "int", Nullable: &yes}, column: newColumn("n", "int", "int", true), want: true},
+		{name: "not null differs", mapping: TypeMapping{DBType: "int", Nullable: &no}, column: newColumn("n", "int", "int", true), want: false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.mapping.Match(c.table, c.column); got != c.want {
+				t.Errorf("%s Match(%s) = %v, want %v", c.mapping, c.column.Name(), got, c.want)
+			}
+		})
+	}
+}
+
+func TestGetColumnType(t *testing.T) {
+	params := &CmdParams{
+		TypeMappings: []TypeMapping{
+			{Column: "*_uuid", Type: "uuid.UUID"},
+			{DBType: "json"}, // invalid, skipped
+		},
+		FieldsTypeMapping: []string{"char(36):string", "json:datatypes.JSON", "invalid"},
+		FieldJSONTypeTag:  true,
+	}
+	cases := []struct {
+		column gorm.ColumnType
+		want   string
+		ok     bool
+	}{
+		{column: newColumn("order_uuid", "char", "char(36)", false), want: "uuid.UUID", ok: true},
+		{column: newColumn("code", "char", "char(36)", false), want: "string", ok: true},
+		{column: newColumn("data", "json", "json", false), want: "datatypes.JSON", ok: true},
+		{column: newColumn("data", "jsonb", "jsonb", false), want: "datatypes.JSON", ok: true},
+		{column: newColumn("name", "varchar", "varchar(64)", false), ok: false},
+	}
+	for _, c := range cases {
+		t.Run(c.column.Name(), func(t *testing.T) {
+			got, ok := params.GetColumnType("orders", c.column)
+			if got != c.want || ok != c.ok {
+				t.Errorf("GetColumnType(%s) = %q, %v, want %q, %v", c.column.Name(), got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
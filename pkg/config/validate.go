@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	gen "gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+const validateTagKey = "validate"
+
+var enumValuesReg = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// validateField set go-playground/validator tag by column constraints and field type after nullable style
+func validateField(columns map[string]gorm.ColumnType, nullableStyle string) gen.ModelOpt {
+	return gen.FieldModify(func(f gen.Field) gen.Field {
+		column, ok := columns[f.ColumnName]
+		if !ok {
+			return f
+		}
+		if rules := validateRules(column, nullableType(nullableStyle, f)); len(rules) > 0 {
+			f.Tag.Set(validateTagKey, strings.Join(rules, ","))
+		}
+		return f
+	})
+}
+
+// validateRules validator rules of column, eg: required,max=64,
+// null types are structs without rules because validator can not check value of them
+func validateRules(column gorm.ColumnType, fieldType string) []string {
+	if _, _, ok := NullValue(fieldType); ok {
+		return nil
+	}
+	var (
+		rules       []string
+		typ         = strings.TrimPrefix(fieldType, "*")
+		dbType      = strings.ToLower(column.DatabaseTypeName())
+		fullType, _ = column.ColumnType()
+		lowerType   = strings.ToLower(fullType)
+	)
+	if nullable, ok := column.Nullable(); ok && nullable {
+		rules = append(rules, "omitempty")
+	} else if pk, _ := column.PrimaryKey(); !pk && typ == "string" {
+		if _, hasDefault := column.DefaultValue(); !hasDefault {
+			rules = append(rules, "required")
+		}
+	}
+	switch {
+	case typ == "string" && strings.HasPrefix(lowerType, "enum"):
+		var values = EnumValues(column)
+		for i, v := range values {
+			values[i] = escapeValidateValue(v)
+		}
+		if len(values) > 0 {
+			rules = append(rules, "oneof="+strings.Join(values, " "))
+		}
+	case typ == "string" && strings.Contains(dbType, "char"):
+		if length, ok := column.Length(); ok && length > 0 {
+			rules = append(rules, fmt.Sprintf("max=%d", length))
+		}
+	case strings.HasPrefix(typ, "float") || isIntegerType(typ):
+		unsigned := strings.Contains(lowerType, "unsigned") && !strings.HasPrefix(typ, "u")
+		var precision, scale int64
+		if strings.Contains(dbType, "decimal") || strings.Contains(dbType, "numeric") {
+			precision, scale, _ = column.DecimalSize()
+		}
+		switch {
+		case precision > 0:
+			bound := fmt.Sprintf("%.0f", math.Pow10(int(precision-scale)))
+			if unsigned {
+				rules = append(rules, "gte=0", "lt="+bound)
+			} else {
+				rules = append(rules, "gt=-"+bound, "lt="+bound)
+			}
+		case unsigned:
+			rules = append(rules, "gte=0")
+		}
+	}
+	if len(rules) == 1 && rules[0] == "omitempty" {
+		return nil
+	}
+	return rules
+}
+
+// EnumValues values of enum column, eg: enum('paid','refunded') => paid, refunded
+func EnumValues(column gorm.ColumnType) []string {
+	fullType, _ := column.ColumnType()
+	if !strings.HasPrefix(strings.ToLower(fullType), "enum") {
+		return nil
+	}
+	var values []string
+	for _, v := range enumValuesReg.FindAllStringSubmatch(fullType[len("enum"):], -1) {
+		values = append(values, strings.ReplaceAll(v[1], "''", "'"))
+	}
+	return values
+}
+
+// escapeValidateValue escape validator separators in value
+func escapeValidateValue(v string) string {
+	v = strings.ReplaceAll(v, ",", "0x2C")
+	v = strings.ReplaceAll(v, "|", "0x7C")
+	if strings.Contains(v, " ") {
+		v = "'" + v + "'"
+	}
+	return v
+}
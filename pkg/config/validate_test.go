@@ -0,0 +1,57 @@
+package config
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	gen "gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/migrator"
+)
+
+func TestValidateRules(t *testing.T) {
+	cases := []struct {
+		name       string
+		goType     string
+		columnType string
+		length     int64
+		nullable   bool
+		style      string
+		want       string
+	}{
+		{name: "not null varchar", goType: "string", columnType: "varchar(64)", length: 64, want: "required,max=64"},
+		{name: "nullable pointer varchar", goType: "*string", columnType: "varchar(64)", length: 64, nullable: true, want: "omitempty,max=64"},
+		{name: "nullable varchar by pointer style", goType: "string", columnType: "varchar(64)", length: 64, nullable: true, style: NullableStylePointer, want: "omitempty,max=64"},
+		{name: "nullable varchar by sqlnull style", goType: "string", columnType: "varchar(64)", length: 64, nullable: true, style: NullableStyleSQLNull},
+		{name: "nullable varchar by generic style", goType: "*string", columnType: "varchar(64)", length: 64, nullable: true, style: NullableStyleGeneric},
+		{name: "nullable varchar by guregu style", goType: "*string", columnType: "varchar(64)", length: 64, nullable: true, style: NullableStyleGuregu},
+		{name: "nullable unsigned by sqlnull style", goType: "*int64", columnType: "bigint unsigned", nullable: true, style: NullableStyleSQLNull},
+		{name: "not null varchar by sqlnull style", goType: "string", columnType: "varchar(64)", length: 64, style: NullableStyleSQLNull, want: "required,max=64"},
+		{name: "enum", goType: "string", columnType: "enum('paid','refunded')", want: "required,oneof=paid refunded"},
+		{name: "unsigned", goType: "int64", columnType: "bigint unsigned", want: "gte=0"},
+		{name: "nullable without rules", goType: "*int64", columnType: "bigint", nullable: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dbType, _, _ := strings.Cut(c.columnType, "(")
+			dbType, _, _ = strings.Cut(dbType, " ")
+			column := migrator.ColumnType{
+				NameValue:       sql.NullString{String: "c", Valid: true},
+				DataTypeValue:   sql.NullString{String: dbType, Valid: true},
+				ColumnTypeValue: sql.NullString{String: c.columnType, Valid: true},
+				NullableValue:   sql.NullBool{Bool: c.nullable, Valid: true},
+				LengthValue:     sql.NullInt64{Int64: c.length, Valid: c.length > 0},
+			}
+			f := reflect.New(reflect.TypeOf(gen.Field(nil)).Elem()).Interface().(gen.Field)
+			f.Name, f.ColumnName, f.Type, f.GORMTag = "C", "c", c.goType, field.GormTag{}
+			if !c.nullable {
+				f.GORMTag.Set(field.TagKeyGormNotNull)
+			}
+			if got := strings.Join(validateRules(column, nullableType(c.style, f)), ","); got != c.want {
+				t.Errorf("validateRules(%s %s) = %q, want %q", c.style, c.goType, got, c.want)
+			}
+		})
+	}
+}
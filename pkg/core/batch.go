@@ -0,0 +1,42 @@
+package core
+
+const safeBatchTmpl = generatedMark + `
+package {{.Package}}
+
+import (
+	"context"
+
+	"{{.ModelPkgPath}}"
+)
+
+// SafeBatchPlaceholderLimit max placeholders of one batch insert statement
+const SafeBatchPlaceholderLimit int32 = {{.Limit}}
+{{range .Models}}
+// CreateInSafeBatches insert rows in batches bounded by SafeBatchPlaceholderLimit
+func ({{.S}} {{.QueryStructName}}) CreateInSafeBatches(ctx context.Context, rows []*{{.ModelPkg}}.{{.ModelStructName}}) error {
+	size := (&{{.ModelPkg}}.{{.ModelStructName}}{}).ComputeSafeSize(SafeBatchPlaceholderLimit)
+	return {{.S}}.{{.QueryStructName}}Do.WithContext(ctx).CreateInBatches(rows, int(size))
+}
+{{end}}`
+
+// GenSafeBatches generate CreateInSafeBatches for query code
+func (g *GenTools) GenSafeBatches() error {
+	models := g.queryModels()
+	if len(models) == 0 {
+		return nil
+	}
+	pkgName, modelPkgPath, err := g.queryPkg()
+	if err != nil {
+		return err
+	}
+	content, err := render(safeBatchTmpl, map[string]interface{}{
+		"Package":      pkgName,
+		"ModelPkgPath": modelPkgPath,
+		"Limit":        g.params.GetPlaceholderLimit(),
+		"Models":       models,
+	})
+	if err != nil {
+		return err
+	}
+	return outputGoFile(g.queryFileName("batch"), content)
+}
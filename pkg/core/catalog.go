@@ -0,0 +1,321 @@
+package core
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"gorm.io/gorm"
+)
+
+// ForeignKey foreign key constraint of table
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+// String eg: fk_orders_user (user_id) -> users (id)
+func (fk ForeignKey) String() string {
+	return fmt.Sprintf("%s (%s) -> %s (%s)", fk.Name, strings.Join(fk.Columns, ", "), fk.RefTable, strings.Join(fk.RefColumns, ", "))
+}
+
+// foreignKeySQL query constraint name, column, referenced schema, whether referenced schema is current schema,
+// referenced table, referenced column, on delete and on update action of table foreign keys
+var foreignKeySQL = map[config.DBType]string{
+	config.DbMySQL: `SELECT k.CONSTRAINT_NAME, k.COLUMN_NAME, '', TRUE, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE
+                     FROM information_schema.KEY_COLUMN_USAGE k
+                     JOIN information_schema.REFERENTIAL_CONSTRAINTS rc
+                     ON rc.CONSTRAINT_SCHEMA = k.TABLE_SCHEMA AND rc.TABLE_NAME = k.TABLE_NAME AND rc.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+                     WHERE k.TABLE_SCHEMA = DATABASE() AND k.TABLE_NAME = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+                     ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`,
+	config.DbPostgres: `SELECT con.conname, att.attname, ns.nspname, ns.nspname = CURRENT_SCHEMA(), cl.relname, fatt.attname,
+                        CASE con.confdeltype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END,
+                        CASE con.confupdtype WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END
+                        FROM pg_constraint con
+                        CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(col, fcol, pos)
+                        JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = k.col
+                        JOIN pg_attribute fatt ON fatt.attrelid = con.confrelid AND fatt.attnum = k.fcol
+                        JOIN pg_class cl ON cl.oid = con.confrelid
+                        JOIN pg_namespace ns ON ns.oid = cl.relnamespace
+                        WHERE con.contype = 'f' AND con.conrelid = CAST(? AS regclass)
+                        ORDER BY con.conname, k.pos`,
+	config.DbSQLite: `SELECT 'fk_' || id, "from", '', 1, "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?) ORDER BY id, seq`,
+	config.DbSQLServer: `SELECT fk.name, pc.name, OBJECT_SCHEMA_NAME(fk.referenced_object_id),
+                         CASE WHEN OBJECT_SCHEMA_NAME(fk.referenced_object_id) = SCHEMA_NAME() THEN 1 ELSE 0 END,
+                         OBJECT_NAME(fk.referenced_object_id), rc.name,
+                         REPLACE(fk.delete_referential_action_desc, '_', ' '), REPLACE(fk.update_referential_action_desc, '_', ' ')
+                         FROM sys.foreign_keys fk
+                         JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+                         JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+                         JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+                         WHERE fk.parent_object_id = OBJECT_ID(?)
+                         ORDER BY fk.name, fkc.constraint_column_id`,
+}
+
+// catalogTable table argument of catalog queries, parts of postgres regclass and sqlserver OBJECT_ID are quoted
+// to resolve mixed case and special names, eg: Billing.Orders => "Billing"."Orders"
+func catalogTable(dbType config.DBType, table string) string {
+	var quote func(string) string
+	switch dbType {
+	case config.DbPostgres:
+		quote = func(v string) string { return `"` + strings.ReplaceAll(v, `"`, `""`) + `"` }
+	case config.DbSQLServer:
+		quote = func(v string) string { return "[" + strings.ReplaceAll(v, "]", "]]") + "]" }
+	default:
+		return table
+	}
+	schema, name := config.SplitSchemaTable(table)
+	if schema == "" {
+		return quote(name)
+	}
+	return quote(schema) + "." + quote(name)
+}
+
+// foreignKeyRefTable referenced table of foreign key, schema qualified when table is schema qualified
+// or referenced table is not in current schema, eg: billing.invoices -> billing.customers
+func foreignKeyRefTable(table, refSchema, refTable string, current bool) string {
+	if schema, _ := config.SplitSchemaTable(table); schema == "" && current {
+		return refTable
+	}
+	return config.SchemaTableName(refSchema, refTable)
+}
+
+// ForeignKeys foreign keys of table from database catalog, empty when database has no foreign key,
+// referenced table is schema qualified like foreignKeyRefTable
+func ForeignKeys(db *gorm.DB, table string) ([]ForeignKey, error) {
+	dbType := config.DBType(db.Dialector.Name())
+	query, ok := foreignKeySQL[dbType]
+	if !ok {
+		return nil, nil
+	}
+	rows, err := db.Raw(query, catalogTable(dbType, table)).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("query foreign keys of table %s fail: %w", table, err)
+	}
+	defer rows.Close()
+	var keys []ForeignKey
+	for rows.Next() {
+		var (
+			name, column, refSchema, refTable, refColumn, onDelete, onUpdate string
+			current                                                          bool
+		)
+		if err = rows.Scan(&name, &column, &refSchema, &current, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return nil, err
+		}
+		if n := len(keys); n > 0 && keys[n-1].Name == name {
+			keys[n-1].Columns = append(keys[n-1].Columns, column)
+			keys[n-1].RefColumns = append(keys[n-1].RefColumns, refColumn)
+			continue
+		}
+		keys = append(keys, ForeignKey{
+			Name:       name,
+			Columns:    []string{column},
+			RefTable:   foreignKeyRefTable(table, refSchema, refTable, current),
+			RefColumns: []string{refColumn},
+			OnDelete:   onDelete,
+			OnUpdate:   onUpdate,
+		})
+	}
+	return keys, rows.Err()
+}
+
+// CheckConstraint check constraint of table
+type CheckConstraint struct {
+	Name   string
+	Clause string
+}
+
+// checkConstraintSQL query constraint name and check clause of table
+var checkConstraintSQL = map[config.DBType]string{
+	config.DbMySQL: `SELECT cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE
+                     FROM information_schema.CHECK_CONSTRAINTS cc
+                     JOIN information_schema.TABLE_CONSTRAINTS tc
+                     ON tc.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND tc.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+                     WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE = 'CHECK'
+                     ORDER BY cc.CONSTRAINT_NAME`,
+	config.DbPostgres: `SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint
+                        WHERE contype = 'c' AND conrelid = CAST(? AS regclass) ORDER BY conname`,
+	config.DbSQLServer: `SELECT name, definition FROM sys.check_constraints WHERE parent_object_id = OBJECT_ID(?) ORDER BY name`,
+}
+
+// CheckConstraints check constraints of table from database catalog, sqlite parsed from create table sql
+func CheckConstraints(db *gorm.DB, table string) ([]CheckConstraint, error) {
+	dbType := config.DBType(db.Dialector.Name())
+	if dbType == config.DbSQLite {
+		var ddl string
+		if err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Row().Scan(&ddl); err != nil {
+			return nil, fmt.Errorf("query create sql of table %s fail: %w", table, err)
+		}
+		return parseCheckConstraints(ddl), nil
+	}
+	query, ok := checkConstraintSQL[dbType]
+	if !ok {
+		return nil, nil
+	}
+	rows, err := db.Raw(query, catalogTable(dbType, table)).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("query check constraints of table %s fail: %w", table, err)
+	}
+	defer rows.Close()
+	var checks []CheckConstraint
+	for rows.Next() {
+		var check CheckConstraint
+		if err = rows.Scan(&check.Name, &check.Clause); err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, rows.Err()
+}
+
+// checkConstraintRegexp CHECK keyword with optional constraint name of create table sql
+var checkConstraintRegexp = regexp.MustCompile("(?i)(?:CONSTRAINT\\s+[\"`\\[]?(\\w+)[\"`\\]]?\\s+)?\\bCHECK\\s*\\(")
+
+// parseCheckConstraints check clauses of create table sql, unnamed check named by position, eg: check_0
+func parseCheckConstraints(ddl string) []CheckConstraint {
+	var checks []CheckConstraint
+	for _, loc := range checkConstraintRegexp.FindAllStringSubmatchIndex(ddl, -1) {
+		var (
+			start = loc[1] - 1
+			depth = 0
+			end   = -1
+		)
+		for i := start; i < len(ddl) && end < 0; i++ {
+			switch ddl[i] {
+			case '(':
+				depth++
+			case ')':
+				if depth--; depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		name := fmt.Sprintf("check_%d", len(checks))
+		if loc[2] >= 0 {
+			name = ddl[loc[2]:loc[3]]
+		}
+		checks = append(checks, CheckConstraint{Name: name, Clause: strings.TrimSpace(ddl[start : end+1])})
+	}
+	return checks
+}
+
+// indexTypeSQL query index name and index method of table
+var indexTypeSQL = map[config.DBType]string{
+	config.DbMySQL: `SELECT DISTINCT INDEX_NAME, INDEX_TYPE FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`,
+	config.DbPostgres: `SELECT ic.relname, am.amname FROM pg_index i
+                        JOIN pg_class ic ON ic.oid = i.indexrelid
+                        JOIN pg_am am ON am.oid = ic.relam
+                        WHERE i.indrelid = CAST(? AS regclass)`,
+	config.DbSQLServer: `SELECT name, type_desc FROM sys.indexes WHERE object_id = OBJECT_ID(?) AND name IS NOT NULL`,
+}
+
+// IndexTypes index method of table indexes by index name, eg: BTREE, gin, NONCLUSTERED
+func IndexTypes(db *gorm.DB, table string) (map[string]string, error) {
+	var (
+		types  = make(map[string]string)
+		dbType = config.DBType(db.Dialector.Name())
+	)
+	query, ok := indexTypeSQL[dbType]
+	if !ok {
+		return types, nil
+	}
+	rows, err := db.Raw(query, catalogTable(dbType, table)).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("query index types of table %s fail: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, typ string
+		if err = rows.Scan(&name, &typ); err != nil {
+			return nil, err
+		}
+		types[name] = typ
+	}
+	return types, rows.Err()
+}
+
+// rowEstimateSQL query estimated row count of table from statistics
+var rowEstimateSQL = map[config.DBType]string{
+	config.DbMySQL:      `SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`,
+	config.DbPostgres:   `SELECT CAST(reltuples AS BIGINT) FROM pg_class WHERE oid = CAST(? AS regclass)`,
+	config.DbSQLServer:  `SELECT SUM(rows) FROM sys.partitions WHERE object_id = OBJECT_ID(?) AND index_id IN (0, 1)`,
+	config.DbClickHouse: `SELECT total_rows FROM system.tables WHERE database = currentDatabase() AND name = ?`,
+}
+
+// EstimateRows estimated row count of table, exact count for database without statistics, false when unknown
+func EstimateRows(db *gorm.DB, table string) (int64, bool) {
+	var (
+		rows   sql.NullInt64
+		dbType = config.DBType(db.Dialector.Name())
+	)
+	if query, ok := rowEstimateSQL[dbType]; ok {
+		if err := db.Raw(query, catalogTable(dbType, table)).Row().Scan(&rows); err != nil || !rows.Valid || rows.Int64 < 0 {
+			return 0, false
+		}
+		return rows.Int64, true
+	}
+	if err := db.Table(table).Count(&rows.Int64).Error; err != nil {
+		return 0, false
+	}
+	return rows.Int64, true
+}
+
+// TableStats statistics of table, invalid when database does not report it
+type TableStats struct {
+	Rows      sql.NullInt64
+	DataSize  sql.NullInt64
+	IndexSize sql.NullInt64
+	Analyzed  sql.NullString
+}
+
+// Size data size with index size of table
+func (s TableStats) Size() int64 {
+	return s.DataSize.Int64 + s.IndexSize.Int64
+}
+
+// tableSizeSQL query data size and index size in bytes of table @table
+var tableSizeSQL = map[config.DBType]string{
+	config.DbMySQL:    `SELECT DATA_LENGTH, INDEX_LENGTH FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = @table`,
+	config.DbPostgres: `SELECT pg_table_size(CAST(@table AS regclass)), pg_indexes_size(CAST(@table AS regclass))`,
+	config.DbSQLite: `SELECT (SELECT SUM(pgsize) FROM dbstat WHERE name = @table),
+                      (SELECT SUM(pgsize) FROM dbstat WHERE name IN (SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = @table))`,
+	config.DbSQLServer: `SELECT CAST(SUM(CASE WHEN index_id IN (0, 1) THEN used_page_count ELSE 0 END) AS BIGINT) * 8192,
+                         CAST(SUM(CASE WHEN index_id > 1 THEN used_page_count ELSE 0 END) AS BIGINT) * 8192
+                         FROM sys.dm_db_partition_stats WHERE object_id = OBJECT_ID(@table)`,
+	config.DbClickHouse: `SELECT toInt64(sum(data_compressed_bytes)), toInt64(sum(primary_key_bytes_in_memory + marks_bytes))
+                          FROM system.parts WHERE active AND database = currentDatabase() AND table = @table`,
+}
+
+// tableAnalyzedSQL query last analyzed time of table @table
+var tableAnalyzedSQL = map[config.DBType]string{
+	config.DbMySQL:     `SELECT last_update FROM mysql.innodb_table_stats WHERE database_name = DATABASE() AND table_name = @table`,
+	config.DbPostgres:  `SELECT GREATEST(last_analyze, last_autoanalyze) FROM pg_stat_all_tables WHERE relid = CAST(@table AS regclass)`,
+	config.DbSQLServer: `SELECT MAX(STATS_DATE(object_id, stats_id)) FROM sys.stats WHERE object_id = OBJECT_ID(@table)`,
+}
+
+// TableStatistics estimated rows, sizes and last analyzed time of table, statistics without permission or support stay invalid
+func TableStatistics(db *gorm.DB, table string) TableStats {
+	var (
+		stats  TableStats
+		dbType = config.DBType(db.Dialector.Name())
+	)
+	if rows, ok := EstimateRows(db, table); ok {
+		stats.Rows = sql.NullInt64{Int64: rows, Valid: true}
+	}
+	if query, ok := tableSizeSQL[dbType]; ok {
+		_ = db.Raw(query, sql.Named("table", catalogTable(dbType, table))).Row().Scan(&stats.DataSize, &stats.IndexSize)
+	}
+	if query, ok := tableAnalyzedSQL[dbType]; ok {
+		_ = db.Raw(query, sql.Named("table", catalogTable(dbType, table))).Row().Scan(&stats.Analyzed)
+	}
+	return stats
+}
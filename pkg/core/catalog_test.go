@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+)
+
+func TestCatalogTable(t *testing.T) {
+	cases := []struct {
+		name   string
+		dbType config.DBType
+		table  string
+		want   string
+	}{
+		{name: "postgres lower case", dbType: config.DbPostgres, table: "orders", want: `"orders"`},
+		{name: "postgres mixed case", dbType: config.DbPostgres, table: "OrderItems", want: `"OrderItems"`},
+		{name: "postgres schema", dbType: config.DbPostgres, table: "Billing.Invoices", want: `"Billing"."Invoices"`},
+		{name: "postgres quote", dbType: config.DbPostgres, table: `odd"name`, want: `"odd""name"`},
+		{name: "sqlserver schema", dbType: config.DbSQLServer, table: "dbo.Order]s", want: "[dbo].[Order]]s]"},
+		{name: "mysql raw", dbType: config.DbMySQL, table: "OrderItems", want: "OrderItems"},
+		{name: "sqlite raw", dbType: config.DbSQLite, table: "order items", want: "order items"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := catalogTable(c.dbType, c.table); got != c.want {
+				t.Errorf("catalogTable(%s, %q) = %q, want %q", c.dbType, c.table, got, c.want)
+			}
+		})
+	}
+}
+
+func TestForeignKeyRefTable(t *testing.T) {
+	cases := []struct {
+		name      string
+		table     string
+		refSchema string
+		refTable  string
+		current   bool
+		want      string
+	}{
+		{name: "current schema", table: "orders", refSchema: "public", refTable: "users", current: true, want: "users"},
+		{name: "other schema", table: "orders", refSchema: "billing", refTable: "invoices", want: "billing.invoices"},
+		{name: "qualified current schema", table: "public.orders", refSchema: "public", refTable: "users", current: true, want: "public.users"},
+		{name: "qualified other schema", table: "billing.invoices", refSchema: "crm", refTable: "customers", want: "crm.customers"},
+		{name: "no schema", table: "orders", refTable: "users", current: true, want: "users"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := foreignKeyRefTable(c.table, c.refSchema, c.refTable, c.current); got != c.want {
+				t.Errorf("foreignKeyRefTable(%q, %q, %q, %v) = %q, want %q", c.table, c.refSchema, c.refTable, c.current, got, c.want)
+			}
+		})
+	}
+}
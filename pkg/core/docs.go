@@ -0,0 +1,335 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"gorm.io/gorm"
+)
+
+const docsIndexName = "index"
+
+const docsMarkdownIndexTmpl = `# {{md .Title}}
+
+| table | comment | rows (estimate) |
+|-------|---------|-----------------|
+{{- range .Tables}}
+| [{{md .Name}}]({{.Page}}) | {{md .Comment}} | {{.Rows}} |
+{{- end}}
+`
+
+const docsMarkdownTableTmpl = `# {{md .Name}}
+
+[index](index.md){{if .Comment}}
+
+{{md .Comment}}{{end}}
+
+rows (estimate): {{.Rows}}
+
+## Columns
+
+| column | type | nullable | default | key | comment |
+|--------|------|----------|---------|-----|---------|
+{{- range .Columns}}
+| {{md .Name}} | {{md .Type}} | {{.Nullable}} | {{md .Default}} | {{.Key}} | {{md .Comment}} |
+{{- end}}
+{{- if .Indexes}}
+
+## Indexes
+
+| name | columns | unique | primary |
+|------|---------|--------|---------|
+{{- range .Indexes}}
+| {{md .Name}} | {{md (join .Columns)}} | {{.Unique}} | {{.Primary}} |
+{{- end}}
+{{- end}}
+{{- if .ForeignKeys}}
+
+## Foreign Keys
+
+| name | columns | references |
+|------|---------|------------|
+{{- range .ForeignKeys}}
+| {{md .Name}} | {{md (join .Columns)}} | {{if page .RefTable}}[{{md .RefTable}}]({{page .RefTable}}){{else}}{{md .RefTable}}{{end}} ({{md (join .RefColumns)}}) |
+{{- end}}
+{{- end}}
+{{- if .ReferencedBy}}
+
+## Referenced By
+
+| table | columns | name |
+|-------|---------|------|
+{{- range .ReferencedBy}}
+| [{{md .Table}}]({{page .Table}}) | {{md (join .ForeignKey.Columns)}} | {{md .ForeignKey.Name}} |
+{{- end}}
+{{- end}}
+`
+
+const docsHTMLHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #24292f; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #d0d7de; padding: 6px 12px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { background: #f6f8fa; padding: 0 4px; }
+a { color: #0969da; text-decoration: none; }
+</style>
+</head>
+<body>
+`
+
+const docsHTMLIndexTmpl = docsHTMLHead + `<h1>{{.Title}}</h1>
+<table>
+<tr><th>table</th><th>comment</th><th>rows (estimate)</th></tr>
+{{- range .Tables}}
+<tr><td><a href="{{.Page}}">{{.Name}}</a></td><td>{{.Comment}}</td><td>{{.Rows}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`
+
+const docsHTMLTableTmpl = docsHTMLHead + `<p><a href="index.html">index</a></p>
+<h1>{{.Name}}</h1>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+<p>rows (estimate): {{.Rows}}</p>
+<h2>Columns</h2>
+<table>
+<tr><th>column</th><th>type</th><th>nullable</th><th>default</th><th>key</th><th>comment</th></tr>
+{{- range .Columns}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Type}}</td><td>{{.Nullable}}</td><td>{{.Default}}</td><td>{{.Key}}</td><td>{{.Comment}}</td></tr>
+{{- end}}
+</table>
+{{- if .Indexes}}
+<h2>Indexes</h2>
+<table>
+<tr><th>name</th><th>columns</th><th>unique</th><th>primary</th></tr>
+{{- range .Indexes}}
+<tr><td>{{.Name}}</td><td>{{join .Columns}}</td><td>{{.Unique}}</td><td>{{.Primary}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .ForeignKeys}}
+<h2>Foreign Keys</h2>
+<table>
+<tr><th>name</th><th>columns</th><th>references</th></tr>
+{{- range .ForeignKeys}}
+<tr><td>{{.Name}}</td><td>{{join .Columns}}</td><td>{{if page .RefTable}}<a href="{{page .RefTable}}">{{.RefTable}}</a>{{else}}{{.RefTable}}{{end}} ({{join .RefColumns}})</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .ReferencedBy}}
+<h2>Referenced By</h2>
+<table>
+<tr><th>table</th><th>columns</th><th>name</th></tr>
+{{- range .ReferencedBy}}
+<tr><td><a href="{{page .Table}}">{{.Table}}</a></td><td>{{join .ForeignKey.Columns}}</td><td>{{.ForeignKey.Name}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`
+
+type (
+	// docTable data dictionary page of table
+	docTable struct {
+		Name         string
+		Comment      string
+		Page         string
+		Rows         string
+		Columns      []docColumn
+		Indexes      []docIndex
+		ForeignKeys  []ForeignKey
+		ReferencedBy []docReference
+	}
+	docColumn struct {
+		Name     string
+		Type     string
+		Nullable string
+		Default  string
+		Key      string
+		Comment  string
+	}
+	docIndex struct {
+		Name    string
+		Columns []string
+		Unique  string
+		Primary string
+	}
+	// docReference foreign key of other table referencing table
+	docReference struct {
+		Table      string
+		ForeignKey ForeignKey
+	}
+)
+
+// GenDocs write data dictionary of tables by docs command
+func (g *GenTools) GenDocs() bool {
+	if !g.params.IsCommand(config.CommandDocs) {
+		return false
+	}
+	if g.params.DSN == "" {
+		log.Fatalln("docs command require dsn option")
+		return true
+	}
+	db := g.GetDB()
+	if db == nil {
+		return true
+	}
+	if err := WriteDocs(db, g.GetTables(), g.params.GetDocsFormat(), g.params.GetDocsOutPath()); err != nil {
+		log.Fatalln("gen docs fail:", err)
+		return true
+	}
+	log.Printf("gen docs success ,docs path: %s \n", g.params.GetDocsOutPath())
+	return true
+}
+
+// WriteDocs write index page and table pages of data dictionary as markdown or html
+func WriteDocs(db *gorm.DB, tables []string, format, dir string) error {
+	var (
+		ext      = ".md"
+		pages    = make(map[string]string, len(tables))
+		values   = make([]*docTable, 0, len(tables))
+		tmplFunc = renderWithFuncs
+		indexTmp = docsMarkdownIndexTmpl
+		tableTmp = docsMarkdownTableTmpl
+	)
+	if format == config.DocsFormatHTML {
+		ext, tmplFunc, indexTmp, tableTmp = ".html", renderHTMLWithFuncs, docsHTMLIndexTmpl, docsHTMLTableTmpl
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create docs path %s fail: %w", dir, err)
+	}
+	for _, t := range tables {
+		pages[t] = strings.ReplaceAll(t, ".", "_") + ext
+	}
+	for _, t := range tables {
+		table, err := newDocTable(db, t)
+		if err != nil {
+			return err
+		}
+		table.Page = pages[t]
+		values = append(values, table)
+	}
+	for _, table := range values {
+		for _, fk := range table.ForeignKeys {
+			for _, ref := range values {
+				if ref.Name == fk.RefTable {
+					ref.ReferencedBy = append(ref.ReferencedBy, docReference{Table: table.Name, ForeignKey: fk})
+				}
+			}
+		}
+	}
+	var funcs = template.FuncMap{
+		"md":   docsMarkdownEscape,
+		"join": func(values []string) string { return strings.Join(values, ", ") },
+		"page": func(table string) string { return pages[table] },
+	}
+	content, err := tmplFunc(indexTmp, funcs, map[string]interface{}{
+		"Title":  "Data Dictionary of " + db.Migrator().CurrentDatabase(),
+		"Tables": values,
+	})
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, docsIndexName+ext), content, 0640); err != nil {
+		return err
+	}
+	for _, table := range values {
+		if content, err = tmplFunc(tableTmp, funcs, map[string]interface{}{
+			"Title":        table.Name,
+			"Name":         table.Name,
+			"Comment":      table.Comment,
+			"Rows":         table.Rows,
+			"Columns":      table.Columns,
+			"Indexes":      table.Indexes,
+			"ForeignKeys":  table.ForeignKeys,
+			"ReferencedBy": table.ReferencedBy,
+		}); err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(dir, table.Page), content, 0640); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newDocTable columns, indexes and foreign keys of table from the same introspection as PrintTable
+func newDocTable(db *gorm.DB, table string) (*docTable, error) {
+	var (
+		migrator = db.Migrator()
+		doc      = &docTable{Name: table, Rows: "-"}
+	)
+	if ty, err := migrator.TableType(table); err == nil && ty != nil {
+		doc.Comment, _ = ty.Comment()
+	}
+	if rows, ok := EstimateRows(db, table); ok {
+		doc.Rows = strconv.FormatInt(rows, 10)
+	}
+	types, err := migrator.ColumnTypes(table)
+	if err != nil {
+		return nil, fmt.Errorf("GORM migrator get columns of table %s fail: %w", table, err)
+	}
+	for _, v := range types {
+		var (
+			keyValue        string
+			uk, _           = v.Unique()
+			pk, _           = v.PrimaryKey()
+			comment, _      = v.Comment()
+			nullable, _     = v.Nullable()
+			defaultValue, _ = v.DefaultValue()
+			typeValue, _    = v.ColumnType()
+		)
+		if pk {
+			keyValue = "pk"
+		} else if uk {
+			keyValue = "uk"
+		}
+		doc.Columns = append(doc.Columns, docColumn{
+			Name:     v.Name(),
+			Type:     typeValue,
+			Nullable: strconv.FormatBool(nullable),
+			Default:  defaultValue,
+			Key:      keyValue,
+			Comment:  comment,
+		})
+	}
+	if indexes, err := migrator.GetIndexes(table); err != nil {
+		log.Printf("get indexes of table %s fail: %s \n", table, err.Error())
+	} else {
+		for _, index := range indexes {
+			unique, _ := index.Unique()
+			primary, _ := index.PrimaryKey()
+			doc.Indexes = append(doc.Indexes, docIndex{
+				Name:    index.Name(),
+				Columns: index.Columns(),
+				Unique:  strconv.FormatBool(unique),
+				Primary: strconv.FormatBool(primary),
+			})
+		}
+	}
+	if doc.ForeignKeys, err = ForeignKeys(db, table); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// docsMarkdownEscape escape markdown table cell
+func docsMarkdownEscape(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(strings.ReplaceAll(value, "\n", " ")), " ")
+}
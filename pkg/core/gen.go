@@ -10,17 +10,19 @@ import (
 	"gorm.io/driver/sqlserver"
 	gen "gorm.io/gen"
 	"gorm.io/gorm"
-	"gorm.io/gorm/schema"
 	"log"
-	"reflect"
+	"path/filepath"
+	"sort"
 )
 
 type (
 	GenTools struct {
-		db     *gorm.DB
-		models []interface{}
-		g      *gen.Generator
-		params *config.CmdParams
+		db          *gorm.DB
+		models      []interface{}
+		modelTables []tableModel
+		g           *gen.Generator
+		params      *config.CmdParams
+		tables      []string
 	}
 	Option func(*GenTools)
 )
@@ -64,23 +66,79 @@ func ConnectDB(t config.DBType, dsn string) (*gorm.DB, error) {
 	}
 }
 
+// TableModelOpts model options depend on table columns
+type TableModelOpts func(table string, columns []gorm.ColumnType) []gen.ModelOpt
+
 // GenModels is gorm/gen generated models
 func GenModels(g *gen.Generator, db *gorm.DB, tables []string, opts ...gen.ModelOpt) (models []interface{}, err error) {
+	return GenTableModels(g, db, tables, nil, opts...)
+}
+
+// GenTableModels is gorm/gen generated models with table column options
+func GenTableModels(g *gen.Generator, db *gorm.DB, tables []string, tableOpts TableModelOpts, opts ...gen.ModelOpt) (models []interface{}, err error) {
+	models, _, err = genTableModels(g, db, tables, tableOpts, opts...)
+	return models, err
+}
+
+// genTableModels generated models and table models sort by table name
+func genTableModels(g *gen.Generator, db *gorm.DB, tables []string, tableOpts TableModelOpts, opts ...gen.ModelOpt) (models []interface{}, modelTables []tableModel, err error) {
 	if len(tables) == 0 {
 		// Execute tasks for all tables in the database
 		tables, err = db.Migrator().GetTables()
 		if err != nil {
-			return nil, fmt.Errorf("GORM migrator get all tables fail: %w", err)
+			return nil, nil, fmt.Errorf("GORM migrator get all tables fail: %w", err)
 		}
 	}
 
 	// Execute some data table tasks
 	models = make([]interface{}, len(tables))
 	for i, tableName := range tables {
-		meta := g.GenerateModel(tableName, opts...)
+		columns, err := db.Migrator().ColumnTypes(tableName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("GORM migrator get columns of table %s fail: %w", tableName, err)
+		}
+		modelOpts := opts
+		if tableOpts != nil {
+			modelOpts = append(tableOpts(tableName, columns), opts...)
+		}
+		meta := g.GenerateModel(tableName, modelOpts...)
+		if meta != nil {
+			modelTables = append(modelTables, newTableModel(meta.FileName, meta.ModelStructName, meta.StructInfo.Package,
+				meta.TableName, meta.TableComment, meta.Fields, columns))
+		}
 		models[i] = meta
 	}
-	return models, nil
+	sort.Slice(modelTables, func(i, j int) bool { return modelTables[i].TableName < modelTables[j].TableName })
+	return models, modelTables, nil
+}
+
+// GetSchemaTables schema qualified tables of schemas, eg: billing.invoices
+func GetSchemaTables(db *gorm.DB, schemas []string) ([]string, error) {
+	const tablesSQL = `SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES 
+                       WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME`
+	var tables []string
+	for _, s := range schemas {
+		var names []string
+		if err := db.Raw(tablesSQL, s).Scan(&names).Error; err != nil {
+			return nil, fmt.Errorf("query tables of schema %s fail: %w", s, err)
+		}
+		for _, name := range names {
+			tables = append(tables, config.SchemaTableName(s, name))
+		}
+	}
+	return tables, nil
+}
+
+// ColumnCount count table columns of model fields, relations excluded
+func ColumnCount(fields []gen.Field) int32 {
+	var size int32
+	for _, f := range fields {
+		if f == nil || f.IsRelation() || f.ColumnName == "" {
+			continue
+		}
+		size++
+	}
+	return size
 }
 
 func (g *GenTools) OpenDB() error {
@@ -89,11 +147,8 @@ func (g *GenTools) OpenDB() error {
 	if g.db, err = ConnectDB(g.params.GetDBType(), g.params.DSN); err != nil {
 		return err
 	}
-	if g.params.ModelNameSignable {
-		g.db.Config.NamingStrategy = schema.NamingStrategy{
-			IdentifierMaxLength: 64,
-			SingularTable:       g.params.ModelNameSignable,
-		}
+	if ns := g.params.GetNamingStrategy(); ns != nil {
+		g.db.Config.NamingStrategy = ns
 	}
 	return nil
 }
@@ -115,9 +170,28 @@ func (g *GenTools) GenModels() error {
 		tables = g.GetTables()
 		opts   = g.params.GetModelOptions()
 	)
-	if g.models, err = GenModels(g.g, db, tables, opts...); err != nil {
+	if g.models, g.modelTables, err = genTableModels(g.g, db, tables, g.params.GetTableModelOptions, opts...); err != nil {
 		return err
 	}
+	// postgres enum types only used by protobuf enums
+	if g.params.WithProto && g.params.GetDBType() == config.DbPostgres {
+		enums, err := postgresEnums(db)
+		if err != nil {
+			return err
+		}
+		for i := range g.modelTables {
+			g.modelTables[i].EnumTypes = enums
+		}
+	}
+	if !g.params.WithGraphQL {
+		return nil
+	}
+	// foreign keys only used by graphql relations
+	for i := range g.modelTables {
+		if g.modelTables[i].ForeignKeys, err = ForeignKeys(db, g.modelTables[i].TableName); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -148,6 +222,35 @@ func (g *GenTools) Execute() {
 		log.Fatalln("generate model require dsn option")
 		return
 	}
+	if g.GetDB() == nil {
+		return
+	}
+	if len(g.params.GetSchemas()) > 0 && !g.params.IsMultiSchema() {
+		log.Printf("schemas only support postgres and sqlserver, ignored for %s \n", g.params.GetDBType())
+	}
+	groups := g.params.SplitPackages(g.GetTables())
+	if len(groups) == 0 {
+		g.generate()
+		return
+	}
+	for _, group := range groups {
+		log.Printf("gen package group %q tables: %v \n", group.Name, group.Tables)
+		g.withGroup(group).generate()
+	}
+}
+
+// withGroup tools generate group tables into group package
+func (g *GenTools) withGroup(group config.PackageGroup) *GenTools {
+	var ins = &GenTools{
+		db:     g.db,
+		params: g.params,
+		tables: group.Tables,
+	}
+	ins.g = gen.NewGenerator(ins.loadConfig(group.OutPath, group.OutFile, group.ModelPkgName))
+	return ins
+}
+
+func (g *GenTools) generate() {
 	g.g.UseDB(g.GetDB())
 	if g.params.OnlyModel {
 		if err := g.GenModels(); err != nil {
@@ -158,13 +261,50 @@ func (g *GenTools) Execute() {
 		g.g.ApplyBasic(g.GetModels()...)
 	}
 	g.g.Execute()
+	models := g.modelTables
+	if err := g.GenFieldSizes(models); err != nil {
+		log.Fatalln("gen field sizes fail:", err)
+		return
+	}
+	if err := g.GenPostgresTypes(models); err != nil {
+		log.Fatalln("gen postgres types fail:", err)
+		return
+	}
+	if err := g.GenSafeBatches(); err != nil {
+		log.Fatalln("gen safe batches fail:", err)
+		return
+	}
+	if err := g.GenUpserts(); err != nil {
+		log.Fatalln("gen upserts fail:", err)
+		return
+	}
+	if err := g.GenProtos(models); err != nil {
+		log.Fatalln("gen protos fail:", err)
+		return
+	}
+	if err := g.GenOpenAPI(models); err != nil {
+		log.Fatalln("gen openapi fail:", err)
+		return
+	}
+	if err := g.GenTypeScript(models); err != nil {
+		log.Fatalln("gen typescript fail:", err)
+		return
+	}
+	if err := g.GenGraphQL(models); err != nil {
+		log.Fatalln("gen graphql fail:", err)
+		return
+	}
 }
 
 func (g *GenTools) LoadConfig() gen.Config {
+	return g.loadConfig(g.params.OutPath, g.params.OutFile, g.params.ModelPkgName)
+}
+
+func (g *GenTools) loadConfig(outPath, outFile, modelPkgName string) gen.Config {
 	var c = gen.Config{
-		OutPath:           g.params.OutPath,
-		OutFile:           g.params.OutFile,
-		ModelPkgPath:      g.params.ModelPkgName,
+		OutPath:           outPath,
+		OutFile:           outFile,
+		ModelPkgPath:      modelPkgName,
 		Mode:              g.params.GetMode(),
 		WithUnitTest:      g.params.WithUnitTest,
 		FieldNullable:     g.params.FieldNullable,
@@ -173,9 +313,14 @@ func (g *GenTools) LoadConfig() gen.Config {
 		FieldWithIndexTag: g.params.FieldWithIndexTag,
 		FieldWithTypeTag:  g.params.FieldWithTypeTag,
 	}
-	// mappings
-	if m := g.params.GetTypeMappings(); len(m) > 0 {
-		c.WithDataTypeMap(m)
+	if ns := g.params.GetModelNameStrategy(); ns != nil {
+		c.WithModelNameStrategy(ns)
+	}
+	if ns := g.params.GetSchemaFileNameStrategy(); ns != nil {
+		c.WithFileNameStrategy(ns)
+	}
+	if ns := g.params.GetJSONTagNameStrategy(); ns != nil {
+		c.WithJSONTagNameStrategy(ns)
 	}
 	// "gorm.io/datatypes"
 	if paths := g.params.GetImportPkgPaths(); len(paths) > 0 {
@@ -208,13 +353,24 @@ func (g *GenTools) GenYAMLConfigFile() bool {
 }
 
 func (g *GenTools) GetTables() []string {
+	if len(g.tables) > 0 {
+		return g.tables
+	}
 	if len(g.params.Tables) > 0 {
 		return g.params.Tables
 	}
 	if g.db == nil {
 		return []string{}
 	}
-	all, err := g.db.Migrator().GetTables()
+	var (
+		all []string
+		err error
+	)
+	if g.params.IsMultiSchema() {
+		all, err = GetSchemaTables(g.db, g.params.GetSchemas())
+	} else {
+		all, err = g.db.Migrator().GetTables()
+	}
 	if err != nil {
 		log.Fatalln("get tables fail:", err)
 		return nil
@@ -228,6 +384,11 @@ func (g *GenTools) GetTables() []string {
 		if _, ok := excludes[t]; ok {
 			continue
 		}
+		if _, name := config.SplitSchemaTable(t); name != t {
+			if _, ok := excludes[name]; ok {
+				continue
+			}
+		}
 		tables = append(tables, t)
 	}
 	return tables
@@ -237,7 +398,10 @@ func (g *GenTools) PrintCmd() bool {
 	if g.PrintHelp() ||
 		g.PrintVersion() ||
 		g.PrintTables() ||
-		g.PrintTableMetaInfo() {
+		g.PrintTableMetaInfo() ||
+		g.GenDocs() ||
+		g.RunLint() ||
+		g.RunValidate() {
 		return true
 	}
 	return false
@@ -255,7 +419,7 @@ func (g *GenTools) PrintTables() bool {
 	if db == nil {
 		return true
 	}
-	return PrintTables(db, g.params.Tables, g.params.ExcludeTableList)
+	return PrintTables(db, g.params.Tables, g.params.ExcludeTableList, g.params.IsTableStats(), g.params.GetSortTables())
 }
 
 func (g *GenTools) PrintTableMetaInfo() bool {
@@ -273,25 +437,78 @@ func (g *GenTools) PrintTableMetaInfo() bool {
 	return PrintTable(db, g.params.ShowTable)
 }
 
+// fieldSizeTmpl GetFieldSize and ComputeSafeSize of model with column count of table and default placeholder limit
+const fieldSizeTmpl = generatedMark + `
+package {{.Model.ModelPkg}}
+
+// {{.Model.ModelStructName}}ColumnCount columns of table {{.Model.TableName}}
+const {{.Model.ModelStructName}}ColumnCount int32 = {{.ColumnCount}}
+
+// GetFieldSize count model columns
+func (*{{.Model.ModelStructName}}) GetFieldSize() int32 {
+	return {{.Model.ModelStructName}}ColumnCount
+}
+
+// ComputeSafeSize compute batch insert safe size under placeholder limit, default limit {{.Limit}}
+func (*{{.Model.ModelStructName}}) ComputeSafeSize(max ...int32) int32 {
+	max = append(max, {{.Limit}})
+	if {{.Model.ModelStructName}}ColumnCount <= 0 || max[0] < {{.Model.ModelStructName}}ColumnCount {
+		return 1
+	}
+	return max[0] / {{.Model.ModelStructName}}ColumnCount
+}
+`
+
+// FieldSizeMethod method set of batch size generated into model code by GenFieldSizes,
+// the stub has no columns
 type FieldSizeMethod struct{}
 
-// GetFieldSize count model fields
+// GetFieldSize count model columns, stub has none
 func (f *FieldSizeMethod) GetFieldSize() int32 {
-	vs := reflect.Indirect(reflect.ValueOf(f))
-	if v := vs.FieldByName("fieldMap"); v.IsValid() {
-		if v.Kind() == reflect.Map {
-			if v.Len() > 0 {
-				return int32(v.Len())
-			}
+	return 0
+}
+
+// ComputeSafeSize compute batch insert safe size under placeholder limit, stub without columns is always 1
+func (f *FieldSizeMethod) ComputeSafeSize(max ...int32) int32 {
+	return 1
+}
+
+// GenFieldSizes generate GetFieldSize and ComputeSafeSize of models into model code
+func (g *GenTools) GenFieldSizes(models []tableModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+	modelPath, err := modelOutputPath(g.g.OutPath, g.g.ModelPkgPath)
+	if err != nil {
+		return err
+	}
+	for _, m := range models {
+		content, err := renderFieldSize(m, g.params.GetPlaceholderLimit())
+		if err != nil {
+			return err
+		}
+		if err = outputGoFile(filepath.Join(modelPath, m.FileName+".size.gen.go"), content); err != nil {
+			return err
 		}
 	}
-	return int32(vs.NumField())
+	return nil
 }
 
-// ComputeSafeSize compute batch insert safe size
-func (f *FieldSizeMethod) ComputeSafeSize(max ...int32) int32 {
-	max = append(max, 65535)
-	return max[0]/f.GetFieldSize() - 100
+// renderFieldSize size methods code of model
+func renderFieldSize(m tableModel, limit int32) ([]byte, error) {
+	return render(fieldSizeTmpl, map[string]interface{}{
+		"Model":       m,
+		"ColumnCount": ColumnCount(m.Fields),
+		"Limit":       limit,
+	})
+}
+
+// SafeSize batch size of generated ComputeSafeSize for columns under placeholder limit, at least 1
+func SafeSize(columns, limit int32) int32 {
+	if columns <= 0 || limit < columns {
+		return 1
+	}
+	return limit / columns
 }
 
 func New(opts ...Option) *GenTools {
@@ -305,6 +522,5 @@ func New(opts ...Option) *GenTools {
 	if ins.g == nil {
 		ins.g = gen.NewGenerator(ins.LoadConfig())
 	}
-	ins.g.WithOpts(gen.WithMethod(FieldSizeMethod{}))
 	return ins
 }
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	gen "gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func newField(name, column string, relation bool) gen.Field {
+	f := reflect.New(reflect.TypeOf(gen.Field(nil)).Elem()).Interface().(gen.Field)
+	f.Name, f.ColumnName = name, column
+	if relation {
+		f.Relation = &field.Relation{}
+	}
+	return f
+}
+
+func TestColumnCount(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields []gen.Field
+		want   int32
+	}{
+		{name: "empty", fields: nil, want: 0},
+		{name: "columns", fields: []gen.Field{newField("ID", "id", false), newField("Name", "name", false)}, want: 2},
+		{name: "relation excluded", fields: []gen.Field{newField("ID", "id", false), newField("Orders", "", true)}, want: 1},
+		{name: "relation with column excluded", fields: []gen.Field{newField("ID", "id", false), newField("User", "user", true)}, want: 1},
+		{name: "empty column name excluded", fields: []gen.Field{newField("ID", "id", false), newField("Extra", "", false)}, want: 1},
+		{name: "nil excluded", fields: []gen.Field{nil, newField("ID", "id", false), nil}, want: 1},
+		{name: "only nil", fields: []gen.Field{nil}, want: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ColumnCount(c.fields); got != c.want {
+				t.Errorf("ColumnCount() = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestComputeSafeSize(t *testing.T) {
+	cases := []struct {
+		name    string
+		columns int32
+		limit   int32
+		want    int32
+	}{
+		{name: "size 0", columns: 0, limit: 65535, want: 1},
+		{name: "negative size", columns: -1, limit: 65535, want: 1},
+		{name: "limit smaller than columns", columns: 10, limit: 5, want: 1},
+		{name: "limit equal to columns", columns: 10, limit: 10, want: 1},
+		{name: "exact divisor", columns: 5, limit: 100, want: 20},
+		{name: "mysql limit", columns: 7, limit: 65535, want: 9362},
+		{name: "sqlserver limit", columns: 7, limit: 2100, want: 300},
+		{name: "old sqlite limit", columns: 7, limit: 999, want: 142},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := SafeSize(c.columns, c.limit); got != c.want {
+				t.Errorf("SafeSize(%d, %d) = %d, want %d", c.columns, c.limit, got, c.want)
+			}
+		})
+	}
+	// stub without generated column count
+	if got := (&FieldSizeMethod{}).ComputeSafeSize(2100); got != 1 {
+		t.Errorf("ComputeSafeSize(2100) = %d, want 1", got)
+	}
+}
+
+func TestRenderFieldSize(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields []gen.Field
+		limit  int32
+		want   []string
+	}{
+		{
+			name:   "columns",
+			fields: []gen.Field{newField("ID", "id", false), newField("Name", "name", false), newField("Orders", "", true)},
+			limit:  65535,
+			want:   []string{"const UserColumnCount int32 = 2", "func (*User) GetFieldSize() int32", "func (*User) ComputeSafeSize(max ...int32) int32", "max = append(max, 65535)"},
+		},
+		{
+			name:  "no columns",
+			limit: 2100,
+			want:  []string{"const UserColumnCount int32 = 0", "max = append(max, 2100)"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := tableModel{FileName: "users", ModelStructName: "User", ModelPkg: "model", TableName: "users", Fields: c.fields}
+			content, err := renderFieldSize(m, c.limit)
+			if err != nil {
+				t.Fatalf("renderFieldSize() error = %v", err)
+			}
+			if _, err = parser.ParseFile(token.NewFileSet(), "users.size.gen.go", content, 0); err != nil {
+				t.Fatalf("renderFieldSize() invalid go code: %v\n%s", err, content)
+			}
+			for _, want := range c.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("renderFieldSize() missing %q\n%s", want, content)
+				}
+			}
+		})
+	}
+}
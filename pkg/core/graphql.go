@@ -0,0 +1,395 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"github.com/jinzhu/inflection"
+)
+
+const (
+	graphQLSchemaFileName = "schema.graphqls"
+	graphQLConfigFileName = "gqlgen.yml"
+)
+
+const graphQLSchemaTmpl = `# Code generated by gorm-tools. DO NOT EDIT.
+{{if .Time}}
+scalar Time
+{{end}}
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+{{range .Types}}
+{{.Description}}
+type {{.Name}} {
+{{- range .Fields}}{{if .Description}}
+  {{.Description}}{{end}}
+  {{.Name}}{{.Args}}: {{.Type}}
+{{- end}}
+}
+
+input {{.Name}}Input {
+{{- range .Inputs}}
+  {{.Name}}: {{.Type}}
+{{- end}}
+}
+
+type {{.Name}}Edge {
+  cursor: String!
+  node: {{.Name}}!
+}
+
+type {{.Name}}Connection {
+  edges: [{{.Name}}Edge!]!
+  pageInfo: PageInfo!
+  totalCount: Int!
+}
+{{end}}
+type Query {
+{{- range .Types}}{{if .Key}}
+  {{.Single}}({{.Key.Name}}: {{.Key.Type}}): {{.Name}}{{end}}
+  {{.Plural}}{{$.PageArgs}}: {{.Name}}Connection!
+{{- end}}
+}
+
+type Mutation {
+{{- range .Types}}
+  create{{.Name}}(input: {{.Name}}Input!): {{.Name}}!{{if .Key}}
+  update{{.Name}}({{.Key.Name}}: {{.Key.Type}}, input: {{.Name}}Input!): {{.Name}}!
+  delete{{.Name}}({{.Key.Name}}: {{.Key.Type}}): Boolean!{{end}}
+{{- end}}
+}
+`
+
+const graphQLConfigTmpl = `# Code generated by gorm-tools. DO NOT EDIT.
+schema:
+  - "*.graphqls"
+
+exec:
+  filename: generated.go
+  package: {{.Package}}
+
+model:
+  filename: models_gen.go
+  package: {{.Package}}
+
+resolver:
+  layout: follow-schema
+  dir: .
+  package: {{.Package}}
+
+models:
+  Int:
+    model:
+      - github.com/99designs/gqlgen/graphql.Int
+      - github.com/99designs/gqlgen/graphql.Int32
+      - github.com/99designs/gqlgen/graphql.Int64
+      - github.com/99designs/gqlgen/graphql.Uint
+      - github.com/99designs/gqlgen/graphql.Uint32
+      - github.com/99designs/gqlgen/graphql.Uint64
+{{- range .Types}}
+  {{.Name}}:
+    model: {{$.ModelPkgPath}}.{{.Name}}
+{{- if .Resolvers}}
+    fields:
+{{- range .Resolvers}}
+      {{.}}:
+        resolver: true
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+// graphQLPageArgs cursor pagination arguments of connection
+const graphQLPageArgs = "(first: Int, after: String)"
+
+type (
+	// graphQLType graphql object type of model
+	graphQLType struct {
+		Name        string
+		Table       string
+		Description string
+		Single      string // query field of one object
+		Plural      string // query field of connection
+		Key         *graphQLField
+		Fields      []graphQLField
+		Inputs      []graphQLField
+		Resolvers   []string // fields of null types resolved by resolvers, gqlgen can not bind them
+	}
+	graphQLField struct {
+		Name        string
+		Type        string
+		Args        string
+		Description string
+		Column      string
+	}
+)
+
+// graphQLTypes graphql scalar of go field type, fields of other types are skipped
+var graphQLTypes = map[string]string{
+	"int8":      "Int",
+	"int16":     "Int",
+	"int32":     "Int",
+	"int":       "Int",
+	"int64":     "Int",
+	"uint8":     "Int",
+	"uint16":    "Int",
+	"uint32":    "Int",
+	"uint":      "Int",
+	"uint64":    "Int",
+	"float32":   "Float",
+	"float64":   "Float",
+	"bool":      "Boolean",
+	"string":    "String",
+	"time.Time": "Time",
+}
+
+// GenGraphQL generate graphql schema and gqlgen config binding types to models
+func (g *GenTools) GenGraphQL(models []tableModel) error {
+	if !g.params.WithGraphQL || len(models) == 0 {
+		return nil
+	}
+	dir := g.params.GetGraphQLOutPath(g.g.OutPath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create graphql out path %s fail: %w", dir, err)
+	}
+	modelPkgPath, err := g.modelPkgPath()
+	if err != nil {
+		return err
+	}
+	types, useTime := newGraphQLTypes(models)
+	content, err := render(graphQLSchemaTmpl, map[string]interface{}{
+		"Time":     useTime,
+		"Types":    types,
+		"PageArgs": graphQLPageArgs,
+	})
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, graphQLSchemaFileName), content, 0640); err != nil {
+		return err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if content, err = render(graphQLConfigTmpl, map[string]interface{}{
+		"Package":      goPackageName(absDir),
+		"ModelPkgPath": modelPkgPath,
+		"Types":        types,
+	}); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, graphQLConfigFileName), content, 0640)
+}
+
+// newGraphQLTypes graphql types of models with relationships of foreign keys
+func newGraphQLTypes(models []tableModel) ([]*graphQLType, bool) {
+	var (
+		types   = make([]*graphQLType, 0, len(models))
+		tables  = make(map[string]*graphQLType, len(models))
+		useTime bool
+	)
+	for _, m := range models {
+		t, hasTime := newGraphQLType(m)
+		useTime = useTime || hasTime
+		types = append(types, t)
+		tables[m.TableName] = t
+	}
+	for i, m := range models {
+		var (
+			child = types[i]
+			refs  = make(map[string]int)
+		)
+		for _, fk := range m.ForeignKeys {
+			refs[fk.RefTable]++
+		}
+		for _, fk := range m.ForeignKeys {
+			parent, ok := tables[fk.RefTable]
+			if !ok || len(fk.Columns) != 1 {
+				continue
+			}
+			column, required := fk.Columns[0], false
+			for _, f := range child.Fields {
+				if f.Column == column {
+					required = strings.HasSuffix(f.Type, "!")
+				}
+			}
+			var (
+				ref      = strings.TrimSuffix(strings.ToLower(column), "_id")
+				name     = graphQLName(ref)
+				typeName = parent.Name
+			)
+			if ref == strings.ToLower(column) || ref == "" {
+				name = graphQLName(parent.Name)
+			}
+			if required {
+				typeName += "!"
+			}
+			child.Fields = append(child.Fields, graphQLField{
+				Name:        graphQLUniqueName(child.Fields, name, "Ref"),
+				Type:        typeName,
+				Description: graphQLDescription("foreign key " + fk.String()),
+			})
+			reverse := child.Plural
+			if refs[fk.RefTable] > 1 {
+				// several foreign keys reference same table, eg: commentsByAuthor, commentsByEditor
+				reverse += "By" + graphQLTypeName(name)
+			}
+			parent.Fields = append(parent.Fields, graphQLField{
+				Name:        graphQLUniqueName(parent.Fields, reverse, "By"+graphQLTypeName(name)),
+				Type:        child.Name + "Connection!",
+				Args:        graphQLPageArgs,
+				Description: graphQLDescription("referenced by " + m.TableName + " " + fk.String()),
+			})
+		}
+	}
+	return types, useTime
+}
+
+// newGraphQLType graphql type of model, nullable column, pointer and null type field are nullable
+func newGraphQLType(m tableModel) (*graphQLType, bool) {
+	var (
+		t = &graphQLType{
+			Name:        m.ModelStructName,
+			Table:       m.TableName,
+			Description: graphQLDescription(strings.TrimSpace(fmt.Sprintf("%s mapped from table <%s> %s", m.ModelStructName, m.TableName, protoComment(m.TableComment)))),
+			Single:      graphQLName(m.ModelStructName),
+			Plural:      graphQLName(inflection.Plural(m.ModelStructName)),
+		}
+		useTime bool
+		keys    []graphQLField
+	)
+	if t.Plural == t.Single {
+		t.Plural += "List"
+	}
+	for _, f := range m.Fields {
+		column, ok := m.Column(f)
+		if !ok {
+			continue
+		}
+		var (
+			goType         = strings.TrimPrefix(f.Type, "*")
+			_, value, null = config.NullValue(goType)
+		)
+		if null {
+			goType = value
+		}
+		typ, ok := graphQLTypes[goType]
+		if !ok {
+			log.Printf("skip field %s.%s of type %s without graphql type \n", m.TableName, f.ColumnName, f.Type)
+			continue
+		}
+		useTime = useTime || typ == "Time"
+		var (
+			nullable, _   = column.Nullable()
+			pk, _         = column.PrimaryKey()
+			_, hasDefault = column.DefaultValue()
+			comment, _    = column.Comment()
+			pointer       = strings.HasPrefix(f.Type, "*") || null
+			field         = graphQLField{Name: graphQLName(f.Name), Type: typ, Column: f.ColumnName}
+			input         = graphQLField{Name: field.Name, Type: typ}
+		)
+		if values := config.EnumValues(column); len(values) > 0 {
+			comment = strings.TrimSpace(comment + " enum: " + strings.Join(values, ", "))
+		}
+		field.Description = graphQLDescription(protoComment(comment))
+		if pk || (!nullable && !pointer) {
+			field.Type += "!"
+		}
+		if !pk && !nullable && !pointer && !hasDefault {
+			input.Type += "!"
+		}
+		if pk {
+			key := field
+			key.Type = typ + "!"
+			keys = append(keys, key)
+		}
+		if null {
+			t.Resolvers = append(t.Resolvers, field.Name)
+		}
+		t.Fields = append(t.Fields, field)
+		t.Inputs = append(t.Inputs, input)
+	}
+	if len(keys) == 1 {
+		t.Key = &keys[0]
+	}
+	return t, useTime
+}
+
+// graphQLUniqueName name not used by fields, name with suffix when used
+func graphQLUniqueName(fields []graphQLField, name, suffix string) string {
+	for _, f := range fields {
+		if f.Name == name {
+			return graphQLUniqueName(fields, name+suffix, suffix)
+		}
+	}
+	return name
+}
+
+// graphQLName lower camel field name of go name, eg: APIKey => apiKey, user_id => userId
+func graphQLName(name string) string {
+	runes := []rune(graphQLTypeName(name))
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// graphQLTypeName pascal name of snake or go name, eg: user_id => UserId
+func graphQLTypeName(name string) string {
+	var buf strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		buf.WriteString(string(runes))
+	}
+	return buf.String()
+}
+
+// graphQLDescription quoted description escaped as graphql string value, control characters escaped as \uXXXX,
+// empty when no description
+func graphQLDescription(description string) string {
+	if description == "" {
+		return ""
+	}
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range description {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				buf.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
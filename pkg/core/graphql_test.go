@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	gen "gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+func TestNewGraphQLType(t *testing.T) {
+	cases := []struct {
+		name     string
+		goType   string
+		nullable bool
+		want     string
+		resolver bool
+	}{
+		{name: "not null", goType: "string", want: "String!"},
+		{name: "pointer", goType: "*string", nullable: true, want: "String"},
+		{name: "sql null", goType: "sql.NullString", nullable: true, want: "String", resolver: true},
+		{name: "generic sql null", goType: "sql.Null[int64]", nullable: true, want: "Int", resolver: true},
+		{name: "guregu time", goType: "null.Time", nullable: true, want: "Time", resolver: true},
+		{name: "guregu value", goType: "null.Value[float64]", nullable: true, want: "Float", resolver: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newField("Nickname", "nickname", false)
+			f.Type = c.goType
+			column := newProtoColumn("nickname", "varchar", "varchar(64)", c.nullable)
+			typ, _ := newGraphQLType(newTableModel("users", "User", "model", "users", "", []gen.Field{f}, []gorm.ColumnType{column}))
+			if len(typ.Fields) != 1 || typ.Fields[0].Type != c.want {
+				t.Fatalf("newGraphQLType(%s) fields = %+v, want type %q", c.goType, typ.Fields, c.want)
+			}
+			if resolver := len(typ.Resolvers) == 1 && typ.Resolvers[0] == "nickname"; resolver != c.resolver {
+				t.Errorf("newGraphQLType(%s) resolvers = %v, want resolver %v", c.goType, typ.Resolvers, c.resolver)
+			}
+		})
+	}
+}
+
+func TestGraphQLDescription(t *testing.T) {
+	cases := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{name: "empty", description: "", want: ""},
+		{name: "plain", description: "user name", want: `"user name"`},
+		{name: "quote and backslash", description: `say "hi" C:\tmp`, want: `"say \"hi\" C:\\tmp"`},
+		{name: "line break and tab", description: "a\nb\tc\r", want: `"a\nb\tc\r"`},
+		{name: "control", description: "bell\a soh\x01 del\x7f", want: `"bell\u0007 soh\u0001 del\u007F"`},
+		{name: "unicode", description: "用户 ✓", want: `"用户 ✓"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := graphQLDescription(c.description); got != c.want {
+				t.Errorf("graphQLDescription(%q) = %s, want %s", c.description, got, c.want)
+			}
+		})
+	}
+}
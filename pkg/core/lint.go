@@ -0,0 +1,385 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"gorm.io/gorm"
+)
+
+const (
+	lintNoPrimaryKey           = "no-primary-key"
+	lintMissingComment         = "missing-comment"
+	lintNullableWithoutDefault = "nullable-without-default"
+	lintFKWithoutIndex         = "fk-without-index"
+	lintColumnNaming           = "column-naming"
+	lintGoKeywordColumn        = "go-keyword-column"
+	lintPoorTypeMapping        = "poor-type-mapping"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type (
+	// lintRule rule of lint command with default level
+	lintRule struct {
+		ID          string
+		Level       string
+		Description string
+	}
+	// lintViolation table or column violating lint rule
+	lintViolation struct {
+		Rule    string `json:"rule"`
+		Level   string `json:"level"`
+		Table   string `json:"table"`
+		Column  string `json:"column,omitempty"`
+		Message string `json:"message"`
+	}
+)
+
+// lintRules rules of lint command, level can be changed by lintRules option
+var lintRules = []lintRule{
+	{lintNoPrimaryKey, config.LintLevelError, "table has no primary key"},
+	{lintMissingComment, config.LintLevelWarning, "table or column has no comment"},
+	{lintNullableWithoutDefault, config.LintLevelWarning, "nullable column has no default value"},
+	{lintFKWithoutIndex, config.LintLevelWarning, "foreign key columns are not leading columns of any index"},
+	{lintColumnNaming, config.LintLevelWarning, "column name is not snake_case"},
+	{lintGoKeywordColumn, config.LintLevelError, "column name is a go keyword"},
+	{lintPoorTypeMapping, config.LintLevelWarning, "column type is mapped poorly by gen without fieldsTypeMapping"},
+}
+
+// genDataTypes go type of database types in gen for mysql and sqlite, other types are generated as string
+var genDataTypes = map[string]string{
+	"numeric": "int32", "integer": "int32", "int": "int32", "smallint": "int32", "mediumint": "int32", "bigint": "int64",
+	"float": "float32", "real": "float64", "double": "float64", "decimal": "float64",
+	"char": "string", "varchar": "string", "tinytext": "string", "mediumtext": "string", "longtext": "string", "text": "string",
+	"binary": "[]byte", "varbinary": "[]byte", "tinyblob": "[]byte", "blob": "[]byte", "mediumblob": "[]byte", "longblob": "[]byte",
+	"json": "string", "enum": "string", "time": "time.Time", "date": "time.Time", "datetime": "time.Time", "timestamp": "time.Time",
+	"year": "int32", "bit": "[]uint8", "boolean": "bool", "tinyint": "int32",
+}
+
+// textTypes database types other than char and text types well mapped to string
+var textTypes = map[string]struct{}{"enum": {}, "set": {}, "name": {}}
+
+// snakeCaseRegexp lower snake case column name
+var snakeCaseRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// RunLint check tables with lint rules by lint command, exit with code 1 on violations
+func (g *GenTools) RunLint() bool {
+	if !g.params.IsCommand(config.CommandLint) {
+		return false
+	}
+	if g.params.DSN == "" {
+		log.Fatalln("lint command require dsn option")
+		return true
+	}
+	db := g.GetDB()
+	if db == nil {
+		return true
+	}
+	levels := g.params.GetLintLevels()
+	for id := range levels {
+		if lintRuleByID(id) == nil {
+			log.Printf("skip unknown lint rule %s \n", id)
+		}
+	}
+	violations, err := Lint(db, g.GetTables(), levels, g.params.GetColumnType)
+	if err != nil {
+		log.Fatalln("lint fail:", err)
+		return true
+	}
+	var (
+		out  io.Writer = os.Stdout
+		file *os.File
+	)
+	if path := g.params.LintOutPath; path != "" {
+		if file, err = os.Create(path); err != nil {
+			log.Fatalln("create lint report fail:", err)
+			return true
+		}
+		out = file
+	}
+	err = writeLintReport(out, g.params.GetLintFormat(), g.params.GetVersion(), levels, violations)
+	if file != nil {
+		_ = file.Close()
+	}
+	if err != nil {
+		log.Fatalln("write lint report fail:", err)
+		return true
+	}
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+	return true
+}
+
+// Lint violations of tables, levels override default level of rules, rules with off level are skipped
+func Lint(db *gorm.DB, tables []string, levels map[string]string, typeMapping func(table string, column gorm.ColumnType) (string, bool)) ([]lintViolation, error) {
+	var (
+		violations []lintViolation
+		migrator   = db.Migrator()
+		dbType     = config.DBType(db.Dialector.Name())
+	)
+	report := func(rule, table, column, format string, args ...interface{}) {
+		if level := lintLevel(rule, levels); level != config.LintLevelOff {
+			violations = append(violations, lintViolation{
+				Rule:    rule,
+				Level:   level,
+				Table:   table,
+				Column:  column,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+	}
+	for _, table := range tables {
+		if dbType == config.DbSQLite && strings.HasPrefix(table, "sqlite_") {
+			continue
+		}
+		columns, err := migrator.ColumnTypes(table)
+		if err != nil {
+			return nil, fmt.Errorf("GORM migrator get columns of table %s fail: %w", table, err)
+		}
+		// sqlite does not support comments
+		withComment := dbType != config.DbSQLite
+		if withComment {
+			var comment string
+			if ty, err := migrator.TableType(table); err == nil && ty != nil {
+				comment, _ = ty.Comment()
+			}
+			if strings.TrimSpace(comment) == "" {
+				report(lintMissingComment, table, "", "table %s has no comment", table)
+			}
+		}
+		var primaryKeys []string
+		for _, column := range columns {
+			var (
+				name                 = column.Name()
+				pk, _                = column.PrimaryKey()
+				nullable, _          = column.Nullable()
+				_, hasDefault        = column.DefaultValue()
+				comment, _           = column.Comment()
+				autoIncrement, _     = column.AutoIncrement()
+				typeName             = strings.ToLower(column.DatabaseTypeName())
+				columnType, _        = column.ColumnType()
+				qualifiedName        = table + "." + name
+				_, configuredMapping = typeMapping(table, column)
+			)
+			if pk {
+				primaryKeys = append(primaryKeys, name)
+			}
+			if withComment && strings.TrimSpace(comment) == "" {
+				report(lintMissingComment, table, name, "column %s has no comment", qualifiedName)
+			}
+			if nullable && !hasDefault && !pk && !autoIncrement {
+				report(lintNullableWithoutDefault, table, name, "nullable column %s has no default value", qualifiedName)
+			}
+			if !snakeCaseRegexp.MatchString(name) {
+				report(lintColumnNaming, table, name, "column %s is not snake_case", qualifiedName)
+			}
+			if token.IsKeyword(name) {
+				report(lintGoKeywordColumn, table, name, "column %s is go keyword %s", qualifiedName, name)
+			}
+			if reason := poorTypeMapping(typeName, columnType, genGoType(dbType, column)); reason != "" && !configuredMapping {
+				report(lintPoorTypeMapping, table, name, "column %s of type %s %s, consider fieldsTypeMapping", qualifiedName, columnType, reason)
+			}
+		}
+		if len(primaryKeys) == 0 {
+			report(lintNoPrimaryKey, table, "", "table %s has no primary key", table)
+		}
+		if lintLevel(lintFKWithoutIndex, levels) == config.LintLevelOff {
+			continue
+		}
+		keys, err := ForeignKeys(db, table)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		indexes, err := migrator.GetIndexes(table)
+		if err != nil {
+			return nil, fmt.Errorf("get indexes of table %s fail: %w", table, err)
+		}
+		var indexColumns = [][]string{primaryKeys}
+		for _, index := range indexes {
+			indexColumns = append(indexColumns, index.Columns())
+		}
+		for _, fk := range keys {
+			if !lintIndexed(fk.Columns, indexColumns) {
+				report(lintFKWithoutIndex, table, strings.Join(fk.Columns, ","), "foreign key %s has no index", fk.String())
+			}
+		}
+	}
+	return violations, nil
+}
+
+// lintIndexed whether columns are leading columns of any index, in any order
+func lintIndexed(columns []string, indexes [][]string) bool {
+	for _, index := range indexes {
+		if len(index) < len(columns) {
+			continue
+		}
+		var leading = make(map[string]struct{}, len(columns))
+		for _, c := range index[:len(columns)] {
+			leading[strings.ToLower(c)] = struct{}{}
+		}
+		covered := true
+		for _, c := range columns {
+			if _, ok := leading[strings.ToLower(c)]; !ok {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// genGoType go type of column generated by gen, scan type of driver except mysql and sqlite
+func genGoType(dbType config.DBType, column gorm.ColumnType) string {
+	if dbType != config.DbMySQL && dbType != config.DbSQLite {
+		if scanType := column.ScanType(); scanType != nil {
+			return scanType.String()
+		}
+	}
+	typeName := strings.ToLower(column.DatabaseTypeName())
+	if columnType, _ := column.ColumnType(); typeName == "tinyint" && strings.HasPrefix(strings.TrimSpace(columnType), "tinyint(1)") {
+		return "bool"
+	}
+	if goType, ok := genDataTypes[typeName]; ok {
+		return goType
+	}
+	return "string"
+}
+
+// poorTypeMapping reason of database type mapped poorly to go type by gen default mapping, empty when mapped well
+func poorTypeMapping(typeName, columnType, goType string) string {
+	decimal := typeName == "numeric" || typeName == "decimal"
+	switch {
+	case strings.HasPrefix(typeName, "_") || strings.HasSuffix(columnType, "[]"):
+		return "is an array mapped to " + goType
+	case decimal && strings.Contains(goType, "int"):
+		return "is mapped to " + goType + " and loses fraction digits"
+	case decimal && strings.Contains(goType, "float"):
+		return "is mapped to " + goType + " and may lose precision"
+	case goType == "string" && !isTextType(typeName):
+		return "is mapped to string"
+	}
+	return ""
+}
+
+// isTextType whether database type is char, text or string type, eg: bpchar, character varying, LowCardinality(String)
+func isTextType(typeName string) bool {
+	if _, ok := textTypes[typeName]; ok {
+		return true
+	}
+	return strings.Contains(typeName, "char") || strings.Contains(typeName, "text") || strings.Contains(typeName, "string")
+}
+
+// lintLevel configured level of rule, default level of rule when not configured
+func lintLevel(id string, levels map[string]string) string {
+	if level := levels[id]; level != "" {
+		return level
+	}
+	if rule := lintRuleByID(id); rule != nil {
+		return rule.Level
+	}
+	return config.LintLevelOff
+}
+
+func lintRuleByID(id string) *lintRule {
+	for i := range lintRules {
+		if lintRules[i].ID == id {
+			return &lintRules[i]
+		}
+	}
+	return nil
+}
+
+// writeLintReport write violations as text, json or sarif report
+func writeLintReport(w io.Writer, format, version string, levels map[string]string, violations []lintViolation) error {
+	switch format {
+	case config.LintFormatJSON:
+		if violations == nil {
+			violations = []lintViolation{}
+		}
+		return writeJSON(w, map[string]interface{}{"violations": violations})
+	case config.LintFormatSARIF:
+		return writeJSON(w, newSARIFReport(version, levels, violations))
+	}
+	var errors, warnings int
+	for _, v := range violations {
+		location := v.Table
+		if v.Column != "" {
+			location += "." + v.Column
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", location, v.Level, v.Message, v.Rule); err != nil {
+			return err
+		}
+		if v.Level == config.LintLevelError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d violations (%d errors, %d warnings)\n", len(violations), errors, warnings)
+	return err
+}
+
+// newSARIFReport sarif 2.1.0 log of violations, tables and columns are logical locations
+func newSARIFReport(version string, levels map[string]string, violations []lintViolation) map[string]interface{} {
+	var (
+		rules   = make([]map[string]interface{}, 0, len(lintRules))
+		results = make([]map[string]interface{}, 0, len(violations))
+	)
+	for _, rule := range lintRules {
+		level := lintLevel(rule.ID, levels)
+		if level == config.LintLevelOff {
+			level = "none"
+		}
+		rules = append(rules, map[string]interface{}{
+			"id":                   rule.ID,
+			"shortDescription":     map[string]string{"text": rule.Description},
+			"defaultConfiguration": map[string]string{"level": level},
+		})
+	}
+	for _, v := range violations {
+		location := map[string]string{"name": v.Table, "fullyQualifiedName": v.Table, "kind": "table"}
+		if v.Column != "" {
+			location = map[string]string{"name": v.Column, "fullyQualifiedName": v.Table + "." + v.Column, "kind": "column"}
+		}
+		results = append(results, map[string]interface{}{
+			"ruleId":    v.Rule,
+			"level":     v.Level,
+			"message":   map[string]string{"text": v.Message},
+			"locations": []interface{}{map[string]interface{}{"logicalLocations": []interface{}{location}}},
+		})
+	}
+	return map[string]interface{}{
+		"$schema": sarifSchema,
+		"version": "2.1.0",
+		"runs": []interface{}{map[string]interface{}{
+			"tool": map[string]interface{}{"driver": map[string]interface{}{
+				"name":           "gentool",
+				"version":        version,
+				"informationUri": "https://github.com/VDHewei/gorm-tools",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"gorm.io/gorm/migrator"
+)
+
+func TestPoorTypeMapping(t *testing.T) {
+	var (
+		stringType = reflect.TypeOf("")
+		timeType   = reflect.TypeOf(time.Time{})
+	)
+	cases := []struct {
+		name       string
+		dbType     config.DBType
+		typeName   string
+		columnType string
+		scanType   reflect.Type
+		want       string
+	}{
+		{name: "pg int4", dbType: config.DbPostgres, typeName: "int4", columnType: "integer", scanType: reflect.TypeOf(int32(0))},
+		{name: "pg int8", dbType: config.DbPostgres, typeName: "int8", columnType: "bigint", scanType: reflect.TypeOf(int64(0))},
+		{name: "pg bool", dbType: config.DbPostgres, typeName: "bool", columnType: "boolean", scanType: reflect.TypeOf(false)},
+		{name: "pg float8", dbType: config.DbPostgres, typeName: "float8", columnType: "double precision", scanType: reflect.TypeOf(float64(0))},
+		{name: "pg timestamptz", dbType: config.DbPostgres, typeName: "timestamptz", columnType: "timestamp with time zone", scanType: timeType},
+		{name: "pg bpchar", dbType: config.DbPostgres, typeName: "bpchar", columnType: "character(2)", scanType: stringType},
+		{name: "pg varchar", dbType: config.DbPostgres, typeName: "varchar", columnType: "character varying(64)", scanType: stringType},
+		{name: "pg uuid", dbType: config.DbPostgres, typeName: "uuid", columnType: "uuid", scanType: stringType, want: "is mapped to string"},
+		{name: "pg jsonb", dbType: config.DbPostgres, typeName: "jsonb", columnType: "jsonb", scanType: stringType, want: "is mapped to string"},
+		{name: "pg numeric", dbType: config.DbPostgres, typeName: "numeric", columnType: "numeric(20,4)", scanType: reflect.TypeOf(float64(0)), want: "is mapped to float64 and may lose precision"},
+		{name: "pg array", dbType: config.DbPostgres, typeName: "_int4", columnType: "integer[]", scanType: stringType, want: "is an array mapped to string"},
+		{name: "mysql varchar", dbType: config.DbMySQL, typeName: "varchar", columnType: "varchar(64)"},
+		{name: "mysql tinyint(1)", dbType: config.DbMySQL, typeName: "tinyint", columnType: "tinyint(1)"},
+		{name: "mysql decimal", dbType: config.DbMySQL, typeName: "decimal", columnType: "decimal(20,4)", want: "is mapped to float64 and may lose precision"},
+		{name: "mysql json", dbType: config.DbMySQL, typeName: "json", columnType: "json", want: "is mapped to string"},
+		{name: "mysql geometry", dbType: config.DbMySQL, typeName: "geometry", columnType: "geometry", want: "is mapped to string"},
+		{name: "mysql scan type ignored", dbType: config.DbMySQL, typeName: "int", columnType: "int", scanType: stringType},
+		{name: "sqlite numeric", dbType: config.DbSQLite, typeName: "numeric", columnType: "numeric", want: "is mapped to int32 and loses fraction digits"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			column := migrator.ColumnType{
+				NameValue:       sql.NullString{String: "c", Valid: true},
+				DataTypeValue:   sql.NullString{String: c.typeName, Valid: true},
+				ColumnTypeValue: sql.NullString{String: c.columnType, Valid: true},
+				ScanTypeValue:   c.scanType,
+			}
+			got := poorTypeMapping(strings.ToLower(c.typeName), c.columnType, genGoType(c.dbType, column))
+			if got != c.want {
+				t.Errorf("poorTypeMapping(%s %s) = %q, want %q", c.dbType, c.columnType, got, c.want)
+			}
+		})
+	}
+}
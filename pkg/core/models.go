@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	gen "gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// tableModel model generated from table with columns of table
+type tableModel struct {
+	FileName        string
+	ModelStructName string
+	ModelPkg        string
+	TableName       string
+	TableComment    string
+	Fields          []gen.Field
+	Columns         map[string]gorm.ColumnType
+	ForeignKeys     []ForeignKey
+	EnumTypes       map[string][]string // values of postgres enum types
+}
+
+// Column column of field, false when field is not a table column
+func (m tableModel) Column(f gen.Field) (gorm.ColumnType, bool) {
+	if f == nil || f.IsRelation() || f.ColumnName == "" {
+		return nil, false
+	}
+	column, ok := m.Columns[f.ColumnName]
+	return column, ok
+}
+
+// EnumValues values of enum column, mysql enum(...) column type or postgres enum type
+func (m tableModel) EnumValues(column gorm.ColumnType) []string {
+	if values := config.EnumValues(column); len(values) > 0 {
+		return values
+	}
+	return m.EnumTypes[column.DatabaseTypeName()]
+}
+
+// postgresEnums values of postgres enum types in sort order
+func postgresEnums(db *gorm.DB) (map[string][]string, error) {
+	const enumsSQL = `SELECT t.typname AS type_name, e.enumlabel AS label FROM pg_catalog.pg_type t
+                      JOIN pg_catalog.pg_enum e ON e.enumtypid = t.oid ORDER BY t.typname, e.enumsortorder`
+	var rows []struct {
+		TypeName string
+		Label    string
+	}
+	if err := db.Raw(enumsSQL).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("query postgres enum types fail: %w", err)
+	}
+	var enums = make(map[string][]string)
+	for _, row := range rows {
+		enums[row.TypeName] = append(enums[row.TypeName], row.Label)
+	}
+	return enums, nil
+}
+
+// jsonProperty json property name and omitempty of field, false when field is ignored by json
+func jsonProperty(f gen.Field) (name string, omitempty bool, ok bool) {
+	name, options, _ := strings.Cut(f.Tag[field.TagKeyJson], ",")
+	if name == "-" && options == "" {
+		return "", false, false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(","+options+",", ",omitempty,"), true
+}
+
+// newTableModel table model of generated model with columns of table
+func newTableModel(fileName, structName, pkg, table, comment string, fields []gen.Field, columnTypes []gorm.ColumnType) tableModel {
+	columns := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, c := range columnTypes {
+		columns[c.Name()] = c
+	}
+	return tableModel{
+		FileName:        fileName,
+		ModelStructName: structName,
+		ModelPkg:        pkg,
+		TableName:       table,
+		TableComment:    comment,
+		Fields:          fields,
+		Columns:         columns,
+	}
+}
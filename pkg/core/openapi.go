@@ -0,0 +1,287 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	openAPIVersion  = "3.1.0"
+	openAPIFileName = "openapi.yaml"
+	// generatedYAMLMark header of yaml files written by gentool
+	generatedYAMLMark = "# Code generated by gorm-tools. DO NOT EDIT.\n"
+)
+
+type (
+	// jsonSchema json schema of table model and columns
+	jsonSchema struct {
+		Schema      string               `json:"$schema,omitempty" yaml:"-"`
+		ID          string               `json:"$id,omitempty" yaml:"-"`
+		Title       string               `json:"title,omitempty" yaml:"title,omitempty"`
+		Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+		Type        interface{}          `json:"type,omitempty" yaml:"type,omitempty"` // type name or type names with null
+		Format      string               `json:"format,omitempty" yaml:"format,omitempty"`
+		Enum        []interface{}        `json:"enum,omitempty" yaml:"enum,omitempty"`
+		MaxLength   int64                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+		Minimum     *int64               `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+		Properties  jsonSchemaProperties `json:"properties,omitempty" yaml:"properties,omitempty"`
+		Required    []string             `json:"required,omitempty" yaml:"required,omitempty"`
+	}
+	// jsonSchemaProperties properties keep column order
+	jsonSchemaProperties []jsonSchemaProperty
+	jsonSchemaProperty   struct {
+		Name   string
+		Schema *jsonSchema
+	}
+	// openAPIDocument openapi document with components only
+	openAPIDocument struct {
+		OpenAPI    string `yaml:"openapi"`
+		Info       openAPIInfo
+		Components struct {
+			Schemas jsonSchemaProperties `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	openAPIInfo struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	}
+	// jsonSchemaType json schema type and format of go type
+	jsonSchemaType struct {
+		Type   string
+		Format string
+	}
+)
+
+// jsonSchemaTypes json schema type of go field type, types not found are any
+var jsonSchemaTypes = map[string]jsonSchemaType{
+	"int8":                   {"integer", "int32"},
+	"int16":                  {"integer", "int32"},
+	"int32":                  {"integer", "int32"},
+	"uint8":                  {"integer", "int32"},
+	"uint16":                 {"integer", "int32"},
+	"uint32":                 {"integer", "int64"},
+	"int":                    {"integer", "int64"},
+	"int64":                  {"integer", "int64"},
+	"uint":                   {"integer", "int64"},
+	"uint64":                 {"integer", "int64"},
+	"soft_delete.DeletedAt":  {"integer", "int64"},
+	"optimisticlock.Version": {"integer", "int64"},
+	"float32":                {"number", "float"},
+	"float64":                {"number", "double"},
+	"bool":                   {"boolean", ""},
+	"string":                 {"string", ""},
+	"[]byte":                 {"string", "byte"},
+	"time.Time":              {"string", "date-time"},
+	"gorm.DeletedAt":         {"string", "date-time"},
+	"datatypes.Date":         {"string", "date"},
+	"datatypes.Time":         {"string", "time"},
+	"datatypes.UUID":         {"string", "uuid"},
+	"null.String":            {"string", ""},
+	"null.Int":               {"integer", "int64"},
+	"null.Int32":             {"integer", "int32"},
+	"null.Int16":             {"integer", "int32"},
+	"null.Byte":              {"integer", "int32"},
+	"null.Float":             {"number", "double"},
+	"null.Bool":              {"boolean", ""},
+	"null.Time":              {"string", "date-time"},
+}
+
+var (
+	// jsonNullTypes go types marshaled as value or null, eg: null.String
+	jsonNullTypes = map[string]struct{}{
+		"gorm.DeletedAt": {},
+		"null.String":    {},
+		"null.Int":       {},
+		"null.Int32":     {},
+		"null.Int16":     {},
+		"null.Byte":      {},
+		"null.Float":     {},
+		"null.Bool":      {},
+		"null.Time":      {},
+	}
+	// jsonSQLNullTypes value field and type of database/sql null types, marshaled as object with Valid,
+	// eg: sql.NullString => {"String": "", "Valid": false}
+	jsonSQLNullTypes = map[string][2]string{
+		"sql.NullString":  {"String", "string"},
+		"sql.NullInt64":   {"Int64", "int64"},
+		"sql.NullInt32":   {"Int32", "int32"},
+		"sql.NullInt16":   {"Int16", "int16"},
+		"sql.NullByte":    {"Byte", "uint8"},
+		"sql.NullFloat64": {"Float64", "float64"},
+		"sql.NullBool":    {"Bool", "bool"},
+		"sql.NullTime":    {"Time", "time.Time"},
+	}
+)
+
+// jsonValueType go type of json value and whether field type marshals null, eg: *string, null.Value[int64]
+func jsonValueType(goType string) (typ string, nullable bool) {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return goType[1:], true
+	case strings.HasPrefix(goType, "null.Value[") && strings.HasSuffix(goType, "]"):
+		return goType[len("null.Value[") : len(goType)-1], true
+	}
+	_, nullable = jsonNullTypes[goType]
+	return goType, nullable
+}
+
+// jsonSQLNullValue value field and go type of database/sql null type, eg: sql.Null[int64] => V int64
+func jsonSQLNullValue(goType string) (name, typ string, ok bool) {
+	if strings.HasPrefix(goType, "sql.Null[") && strings.HasSuffix(goType, "]") {
+		return "V", goType[len("sql.Null[") : len(goType)-1], true
+	}
+	v, ok := jsonSQLNullTypes[goType]
+	return v[0], v[1], ok
+}
+
+func (p jsonSchemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, v := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (p jsonSchemaProperties) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, v := range p {
+		var value yaml.Node
+		if err := value.Encode(v.Schema); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: v.Name}, &value)
+	}
+	return node, nil
+}
+
+// GenOpenAPI generate json schema of tables and openapi components schemas
+func (g *GenTools) GenOpenAPI(models []tableModel) error {
+	if !g.params.WithOpenAPI || len(models) == 0 {
+		return nil
+	}
+	dir := g.params.GetOpenAPIOutPath(g.g.OutPath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create openapi out path %s fail: %w", dir, err)
+	}
+	var doc = openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: g.GetDB().Migrator().CurrentDatabase() + " tables", Version: "1.0.0"},
+	}
+	for _, m := range models {
+		schema := newJSONSchema(m)
+		doc.Components.Schemas = append(doc.Components.Schemas, jsonSchemaProperty{Name: m.ModelStructName, Schema: schema})
+
+		file := *schema
+		file.Schema, file.ID = jsonSchemaDraft, m.FileName+".schema.json"
+		content, err := json.MarshalIndent(&file, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(dir, file.ID), append(content, '\n'), 0640); err != nil {
+			return err
+		}
+	}
+	var buf = bytes.NewBufferString(generatedYAMLMark)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, openAPIFileName), buf.Bytes(), 0640)
+}
+
+// newJSONSchema json schema of table model, property names follow json tags of fields
+func newJSONSchema(m tableModel) *jsonSchema {
+	var schema = &jsonSchema{
+		Title:       m.ModelStructName,
+		Description: protoComment(m.TableComment),
+		Type:        "object",
+	}
+	for _, f := range m.Fields {
+		column, ok := m.Column(f)
+		if !ok {
+			continue
+		}
+		name, omitempty, ok := jsonProperty(f)
+		if !ok {
+			continue
+		}
+		var (
+			typ, nullable                 = jsonValueType(f.Type)
+			valueName, valueType, sqlNull = jsonSQLNullValue(typ)
+		)
+		if sqlNull {
+			typ = valueType
+		}
+		var (
+			t, typed    = jsonSchemaTypes[typ]
+			comment, _  = column.Comment()
+			fullType, _ = column.ColumnType()
+			property    = &jsonSchema{Format: t.Format}
+		)
+		switch {
+		case !typed:
+		case nullable:
+			property.Type = []string{t.Type, "null"}
+		default:
+			property.Type = t.Type
+		}
+		if values := config.EnumValues(column); len(values) > 0 {
+			for _, v := range values {
+				property.Enum = append(property.Enum, v)
+			}
+			if nullable {
+				property.Enum = append(property.Enum, nil)
+			}
+		} else if t.Type == "string" && t.Format == "" && strings.Contains(strings.ToLower(column.DatabaseTypeName()), "char") {
+			if length, ok := column.Length(); ok && length > 0 {
+				property.MaxLength = length
+			}
+		}
+		if t.Type == "integer" || t.Type == "number" {
+			if strings.Contains(strings.ToLower(fullType), "unsigned") || strings.HasPrefix(typ, "uint") {
+				property.Minimum = new(int64)
+			}
+		}
+		if sqlNull {
+			// database/sql null types have no json marshaler, marshaled as object of value and Valid
+			property = &jsonSchema{
+				Type: "object",
+				Properties: jsonSchemaProperties{
+					{Name: valueName, Schema: property},
+					{Name: "Valid", Schema: &jsonSchema{Type: "boolean"}},
+				},
+				Required: []string{valueName, "Valid"},
+			}
+		}
+		property.Description = protoComment(comment)
+		schema.Properties = append(schema.Properties, jsonSchemaProperty{Name: name, Schema: property})
+		if !omitempty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
@@ -0,0 +1,407 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+)
+
+const (
+	pgRangeFileName = "pg_range.gen.go"
+	pgNetFileName   = "pg_net.gen.go"
+)
+
+const pgRangeTmpl = generatedMark + `
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+{{range .Ranges}}
+// {{.Name}} postgres {{.DBType}} column
+type {{.Name}} struct {
+	pgtype.Range[{{.Element}}]
+}
+
+// Scan implements sql.Scanner
+func (r *{{.Name}}) Scan(src interface{}) error {
+	return scanPgRange({{.OID}}, src, &r.Range)
+}
+
+// Value implements driver.Valuer
+func (r {{.Name}}) Value() (driver.Value, error) {
+	return valuePgRange({{.OID}}, r.Range)
+}
+{{end}}
+// scanPgRange scan text format range of oid into dst
+func scanPgRange(oid uint32, src interface{}, dst interface{}) error {
+	var value []byte
+	switch v := src.(type) {
+	case nil:
+	case string:
+		value = []byte(v)
+	case []byte:
+		value = v
+	default:
+		return fmt.Errorf("cannot scan %T into range", src)
+	}
+	return pgtype.NewMap().Scan(oid, pgtype.TextFormatCode, value, dst)
+}
+
+// valuePgRange text format of range, nil of invalid range
+func valuePgRange(oid uint32, src interface{}) (driver.Value, error) {
+	value, err := pgtype.NewMap().Encode(oid, pgtype.TextFormatCode, src, nil)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return string(value), nil
+}
+`
+
+const pgNetTmpl = generatedMark + `
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/netip"
+)
+{{if .PgInet}}
+// PgInet postgres inet column of host address
+type PgInet struct {
+	netip.Addr
+}
+
+// Scan implements sql.Scanner
+func (a *PgInet) Scan(src interface{}) error {
+	text, err := pgNetText(src)
+	if err != nil || text == "" {
+		a.Addr = netip.Addr{}
+		return err
+	}
+	prefix, err := netip.ParsePrefix(text)
+	if err != nil {
+		a.Addr, err = netip.ParseAddr(text)
+		return err
+	}
+	if !prefix.IsSingleIP() {
+		return fmt.Errorf("inet %s is not a host address", text)
+	}
+	a.Addr = prefix.Addr()
+	return nil
+}
+
+// Value implements driver.Valuer
+func (a PgInet) Value() (driver.Value, error) {
+	if !a.IsValid() {
+		return nil, nil
+	}
+	return a.String(), nil
+}
+{{end}}{{if .PgCidr}}
+// PgCidr postgres cidr column of network
+type PgCidr struct {
+	netip.Prefix
+}
+
+// Scan implements sql.Scanner
+func (p *PgCidr) Scan(src interface{}) error {
+	text, err := pgNetText(src)
+	if err != nil || text == "" {
+		p.Prefix = netip.Prefix{}
+		return err
+	}
+	p.Prefix, err = netip.ParsePrefix(text)
+	return err
+}
+
+// Value implements driver.Valuer
+func (p PgCidr) Value() (driver.Value, error) {
+	if !p.IsValid() {
+		return nil, nil
+	}
+	return p.String(), nil
+}
+{{end}}{{if .PgMacaddr}}
+// PgMacaddr postgres macaddr column
+type PgMacaddr struct {
+	net.HardwareAddr
+}
+
+// Scan implements sql.Scanner
+func (m *PgMacaddr) Scan(src interface{}) error {
+	text, err := pgNetText(src)
+	if err != nil || text == "" {
+		m.HardwareAddr = nil
+		return err
+	}
+	m.HardwareAddr, err = net.ParseMAC(text)
+	return err
+}
+
+// Value implements driver.Valuer
+func (m PgMacaddr) Value() (driver.Value, error) {
+	if len(m.HardwareAddr) == 0 {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (m PgMacaddr) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (m *PgMacaddr) UnmarshalText(text []byte) error {
+	return m.Scan(text)
+}
+{{end}}
+// pgNetText text format of network column, empty of null
+func pgNetText(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("cannot scan %T into network address", src)
+	}
+}
+`
+
+const pgFieldTmpl = generatedMark + `
+package {{.Package}}
+
+import (
+	"gorm.io/gen/field"
+)
+{{if .Array}}
+// ArrayField postgres array column with array operators
+type ArrayField struct{ field.Field }
+
+// AsArray array operators of column, eg: AsArray(u.Tags).Contains(pq.StringArray{"go"})
+func AsArray(f field.Field) ArrayField { return ArrayField{f} }
+
+// Contains column contains all elements of value: column @> value
+func (a ArrayField) Contains(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? @> ?", a.Field, value)
+}
+
+// ContainedBy all elements of column are in value: column <@ value
+func (a ArrayField) ContainedBy(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? <@ ?", a.Field, value)
+}
+
+// Overlaps column and value have common elements: column && value
+func (a ArrayField) Overlaps(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? && ?", a.Field, value)
+}
+
+// Any element equals to value: value = ANY(column)
+func (a ArrayField) Any(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? = ANY(?)", value, a.Field)
+}
+{{end}}{{if .Range}}
+// RangeField postgres range column with range operators
+type RangeField struct {
+	field.Field
+	subType string
+}
+{{range .Ranges}}
+// As{{trimPg .Name}} range operators of {{.DBType}} column, eg: As{{trimPg .Name}}(b.Period).Overlaps(value)
+func As{{trimPg .Name}}(f field.Field) RangeField { return RangeField{Field: f, subType: {{printf "%q" .SubType}}} }
+{{end}}
+// ContainsElement column contains element value: column @> CAST(value AS subtype)
+func (r RangeField) ContainsElement(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? @> CAST(? AS "+r.subType+")", r.Field, value)
+}
+
+// Contains column contains range value: column @> value
+func (r RangeField) Contains(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? @> ?", r.Field, value)
+}
+
+// ContainedBy column is contained by range value: column <@ value
+func (r RangeField) ContainedBy(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? <@ ?", r.Field, value)
+}
+
+// Overlaps column and range value have common points: column && value
+func (r RangeField) Overlaps(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? && ?", r.Field, value)
+}
+
+// StrictlyLeft column is strictly left of range value: column << value
+func (r RangeField) StrictlyLeft(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? << ?", r.Field, value)
+}
+
+// StrictlyRight column is strictly right of range value: column >> value
+func (r RangeField) StrictlyRight(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? >> ?", r.Field, value)
+}
+
+// Adjacent column is adjacent to range value: column -|- value
+func (r RangeField) Adjacent(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? -|- ?", r.Field, value)
+}
+{{end}}{{if .Hstore}}
+// HstoreField postgres hstore column with hstore operators
+type HstoreField struct{ field.Field }
+
+// AsHstore hstore operators of column, eg: AsHstore(p.Attrs).HasKey("color")
+func AsHstore(f field.Field) HstoreField { return HstoreField{f} }
+
+// HasKey column contains key: exist(column, key)
+func (h HstoreField) HasKey(key string) field.Expr {
+	return field.NewUnsafeFieldRaw("exist(?, ?)", h.Field, key)
+}
+
+// Contains column contains all pairs of value: column @> value
+func (h HstoreField) Contains(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? @> ?", h.Field, value)
+}
+
+// ContainedBy all pairs of column are in value: column <@ value
+func (h HstoreField) ContainedBy(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? <@ ?", h.Field, value)
+}
+{{end}}{{if .Geometry}}
+// GeometryField postgres geometric column with geometric operators
+type GeometryField struct{ field.Field }
+
+// AsGeometry geometric operators of column, eg: AsGeometry(s.Location).DistanceLt(pgtype.Point{...}, 10)
+func AsGeometry(f field.Field) GeometryField { return GeometryField{f} }
+
+// Contains column contains value: column @> value
+func (g GeometryField) Contains(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? @> ?", g.Field, value)
+}
+
+// ContainedBy column is contained by value: column <@ value
+func (g GeometryField) ContainedBy(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? <@ ?", g.Field, value)
+}
+
+// Overlaps column and value overlap: column && value
+func (g GeometryField) Overlaps(value interface{}) field.Expr {
+	return field.NewUnsafeFieldRaw("? && ?", g.Field, value)
+}
+
+// DistanceLt distance between column and value is less than distance: column <-> value < distance
+func (g GeometryField) DistanceLt(value interface{}, distance float64) field.Expr {
+	return field.NewUnsafeFieldRaw("? <-> ? < ?", g.Field, value, distance)
+}
+{{end}}`
+
+// GenPostgresTypes generate range and network types into model code and array, range, hstore and geometric operators into query code
+func (g *GenTools) GenPostgresTypes(models []tableModel) error {
+	if g.params.GetDBType() != config.DbPostgres {
+		return nil
+	}
+	var (
+		kinds      = make(map[string]bool)
+		rangeTypes = make(map[string]struct{}, len(config.PostgresRanges))
+		netTypes   = make(map[string]bool, len(config.PostgresNetTypes))
+		withRange  bool
+	)
+	for _, r := range config.PostgresRanges {
+		rangeTypes[r.Name] = struct{}{}
+	}
+	for _, m := range models {
+		for _, f := range m.Fields {
+			column, ok := m.Column(f)
+			if !ok {
+				continue
+			}
+			if kind := config.PostgresTypeKind(column.DatabaseTypeName()); kind != "" {
+				kinds[kind] = true
+			}
+			typ := strings.TrimPrefix(f.Type, "*")
+			if _, ok = rangeTypes[typ]; ok {
+				withRange = true
+			}
+			for _, name := range config.PostgresNetTypes {
+				if typ == name {
+					netTypes[name] = true
+				}
+			}
+		}
+	}
+	if withRange {
+		if err := g.genPgRanges(); err != nil {
+			return err
+		}
+	}
+	if len(netTypes) > 0 {
+		if err := g.genPgNetTypes(netTypes); err != nil {
+			return err
+		}
+	}
+	if g.params.OnlyModel || len(kinds) == 0 {
+		return nil
+	}
+	content, err := renderWithFuncs(pgFieldTmpl, template.FuncMap{
+		"trimPg": func(name string) string { return strings.TrimPrefix(name, "Pg") },
+	}, map[string]interface{}{
+		"Package":  filepath.Base(g.g.OutPath),
+		"Ranges":   config.PostgresRanges,
+		"Array":    kinds[config.PostgresKindArray],
+		"Range":    kinds[config.PostgresKindRange],
+		"Hstore":   kinds[config.PostgresKindHstore],
+		"Geometry": kinds[config.PostgresKindGeometry],
+	})
+	if err != nil {
+		return err
+	}
+	return outputGoFile(g.queryFileName("pg_field"), content)
+}
+
+// genPgNetTypes write used network types with sql.Scanner and driver.Valuer into model code
+func (g *GenTools) genPgNetTypes(types map[string]bool) error {
+	modelPath, err := modelOutputPath(g.g.OutPath, g.g.ModelPkgPath)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(modelPath, os.ModePerm); err != nil {
+		return err
+	}
+	var data = map[string]interface{}{"Package": filepath.Base(modelPath)}
+	for name := range types {
+		data[name] = true
+	}
+	content, err := render(pgNetTmpl, data)
+	if err != nil {
+		return err
+	}
+	return outputGoFile(filepath.Join(modelPath, pgNetFileName), content)
+}
+
+// genPgRanges write range types with sql.Scanner and driver.Valuer into model code
+func (g *GenTools) genPgRanges() error {
+	modelPath, err := modelOutputPath(g.g.OutPath, g.g.ModelPkgPath)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(modelPath, os.ModePerm); err != nil {
+		return err
+	}
+	content, err := render(pgRangeTmpl, map[string]interface{}{
+		"Package": filepath.Base(modelPath),
+		"Ranges":  config.PostgresRanges,
+	})
+	if err != nil {
+		return err
+	}
+	return outputGoFile(filepath.Join(modelPath, pgRangeFileName), content)
+}
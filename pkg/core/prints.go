@@ -1,7 +1,9 @@
 package core
 
 import (
+	"database/sql"
 	"fmt"
+	"github.com/VDHewei/gorm-tools/pkg/config"
 	"github.com/liushuochen/gotable"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -9,10 +11,12 @@ import (
 	"log"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 )
 
-func PrintTables(db *gorm.DB, tables []string, excludes []string) bool {
+// PrintTables print tables with comment, statistics of tables when withStats, sortBy name|rows|size
+func PrintTables(db *gorm.DB, tables []string, excludes []string, withStats bool, sortBy string) bool {
 	var migrator = db.Migrator()
 	all, err := migrator.GetTables()
 	if err != nil {
@@ -55,20 +59,45 @@ func PrintTables(db *gorm.DB, tables []string, excludes []string) bool {
 		return true
 	}
 	sort.Strings(values)
-	printTable, err := gotable.Create("table_name", "comment")
+	var (
+		m        = newExtrasMigrate(db, migrator)
+		comments = make(map[string]string, len(values))
+		stats    = make(map[string]TableStats, len(values))
+	)
+	for i, t := range values {
+		if ty, err := m.TableType(t); err == nil && ty != nil && ty.Name() != "" {
+			values[i] = ty.Name()
+			comments[values[i]], _ = ty.Comment()
+		}
+		if withStats {
+			stats[values[i]] = TableStatistics(db, values[i])
+		}
+	}
+	switch sortBy {
+	case config.SortTablesByRows:
+		sort.SliceStable(values, func(i, j int) bool { return stats[values[i]].Rows.Int64 > stats[values[j]].Rows.Int64 })
+	case config.SortTablesBySize:
+		sort.SliceStable(values, func(i, j int) bool { return stats[values[i]].Size() > stats[values[j]].Size() })
+	}
+	var columns = []string{"table_name", "comment"}
+	if withStats {
+		columns = append(columns, "rows", "data_size", "index_size", "last_analyzed")
+	}
+	printTable, err := gotable.Create(columns...)
 	if err != nil {
 		log.Fatalln("Create table failed: ", err.Error())
 		return true
 	}
-	var m = newExtrasMigrate(db, migrator)
 	for _, t := range values {
-		ty, _ := m.TableType(t)
-		if ty == nil {
-			printTable.AddRow([]string{t, ""})
-		} else {
-			comment, _ := ty.Comment()
-			printTable.AddRow([]string{ty.Name(), comment})
+		var row = []string{t, comments[t]}
+		if withStats {
+			s := stats[t]
+			row = append(row, formatCount(s.Rows), formatSize(s.DataSize), formatSize(s.IndexSize), "n/a")
+			if s.Analyzed.Valid && s.Analyzed.String != "" {
+				row[len(row)-1] = s.Analyzed.String
+			}
 		}
+		printTable.AddRow(row)
 	}
 	fmt.Println(printTable)
 	return true
@@ -133,9 +162,110 @@ func PrintTable(db *gorm.DB, tableName string) bool {
 		fmt.Println("<" + tableName + "> -- " + tableComment)
 	}
 	fmt.Println(printTable)
+	printTableIndexes(db, tableName)
+	printTableForeignKeys(db, tableName)
+	printTableChecks(db, tableName)
 	return true
 }
 
+// printTableIndexes print indexes of table, index type from dialect catalog
+func printTableIndexes(db *gorm.DB, tableName string) {
+	indexes, err := db.Migrator().GetIndexes(tableName)
+	if err != nil {
+		log.Printf("get indexes of table %s fail: %s \n", tableName, err.Error())
+		return
+	}
+	if len(indexes) == 0 {
+		return
+	}
+	types, err := IndexTypes(db, tableName)
+	if err != nil {
+		log.Printf("%s \n", err.Error())
+	}
+	printTable, err := gotable.Create("index", "columns", "unique", "primary", "type")
+	if err != nil {
+		log.Fatalln("Create table failed: ", err.Error())
+		return
+	}
+	for _, v := range indexes {
+		unique, _ := v.Unique()
+		primary, _ := v.PrimaryKey()
+		printTable.AddRow([]string{v.Name(), strings.Join(v.Columns(), ", "), fmt.Sprintf("%v", unique), fmt.Sprintf("%v", primary), types[v.Name()]})
+	}
+	fmt.Println("indexes:")
+	fmt.Println(printTable)
+}
+
+// printTableForeignKeys print foreign keys of table
+func printTableForeignKeys(db *gorm.DB, tableName string) {
+	keys, err := ForeignKeys(db, tableName)
+	if err != nil {
+		log.Printf("%s \n", err.Error())
+		return
+	}
+	if len(keys) == 0 {
+		return
+	}
+	printTable, err := gotable.Create("foreign_key", "columns", "references", "on_delete", "on_update")
+	if err != nil {
+		log.Fatalln("Create table failed: ", err.Error())
+		return
+	}
+	for _, v := range keys {
+		references := fmt.Sprintf("%s (%s)", v.RefTable, strings.Join(v.RefColumns, ", "))
+		printTable.AddRow([]string{v.Name, strings.Join(v.Columns, ", "), references, v.OnDelete, v.OnUpdate})
+	}
+	fmt.Println("foreign keys:")
+	fmt.Println(printTable)
+}
+
+// printTableChecks print check constraints of table
+func printTableChecks(db *gorm.DB, tableName string) {
+	checks, err := CheckConstraints(db, tableName)
+	if err != nil {
+		log.Printf("%s \n", err.Error())
+		return
+	}
+	if len(checks) == 0 {
+		return
+	}
+	printTable, err := gotable.Create("check", "clause")
+	if err != nil {
+		log.Fatalln("Create table failed: ", err.Error())
+		return
+	}
+	for _, v := range checks {
+		printTable.AddRow([]string{v.Name, v.Clause})
+	}
+	fmt.Println("check constraints:")
+	fmt.Println(printTable)
+}
+
+// formatCount row count of statistics, n/a when unknown
+func formatCount(v sql.NullInt64) string {
+	if !v.Valid {
+		return "n/a"
+	}
+	return strconv.FormatInt(v.Int64, 10)
+}
+
+// formatSize human readable bytes of statistics, eg: 16.0 KiB, n/a when unknown
+func formatSize(v sql.NullInt64) string {
+	if !v.Valid {
+		return "n/a"
+	}
+	const unit = 1024
+	if v.Int64 < unit {
+		return fmt.Sprintf("%d B", v.Int64)
+	}
+	div, exp := int64(unit), 0
+	for n := v.Int64 / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(v.Int64)/float64(div), "KMGTPE"[exp])
+}
+
 type migratorImpl struct {
 	db *gorm.DB
 	m  gorm.Migrator
@@ -0,0 +1,522 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+)
+
+const (
+	protoTimestampImport = "google/protobuf/timestamp.proto"
+	protoWrappersImport  = "google/protobuf/wrappers.proto"
+	protoTimestampType   = "google.protobuf.Timestamp"
+)
+
+const protoFileTmpl = `// Code generated by gorm-tools. DO NOT EDIT.
+// source table: {{.Table}}
+
+syntax = "proto3";
+
+package {{.Package}};
+{{range .Imports}}
+import "{{.}}";
+{{- end}}
+
+option go_package = "{{.GoPackage}}";
+
+// {{.Name}} mapped from table <{{.Table}}>{{if .Comment}}
+// {{.Comment}}{{end}}
+message {{.Name}} {
+{{- range .Fields}}{{if .Comment}}
+  // {{.Comment}}{{end}}
+  {{.Type}} {{.Name}} = {{.Number}};
+{{- end}}
+}
+{{range .Enums}}
+enum {{.Name}} {
+{{- range .Values}}
+  {{.Name}} = {{.Number}};{{if .Value}} // {{.Value}}{{end}}
+{{- end}}
+}
+{{end}}`
+
+const protoConvertTmpl = generatedMark + `
+package {{.GoPackageName}}
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/plugin/optimisticlock"
+	"gorm.io/plugin/soft_delete"
+	"github.com/lib/pq"
+
+	"{{.ModelPkgPath}}"
+)
+{{range $e := .Enums}}
+var {{.FromModel}} = map[string]{{.Name}}{
+{{- range .Values}}{{if .Number}}
+	{{printf "%q" .Value}}: {{$e.Name}}_{{.Name}},{{end}}
+{{- end}}
+}
+
+var {{.ToModel}} = map[{{.Name}}]string{
+{{- range .Values}}{{if .Number}}
+	{{$e.Name}}_{{.Name}}: {{printf "%q" .Value}},{{end}}
+{{- end}}
+}
+{{end}}
+// {{.Name}}FromModel convert {{.ModelPkg}}.{{.Model}} to {{.Name}} message
+func {{.Name}}FromModel(m *{{.ModelPkg}}.{{.Model}}) *{{.Name}} {
+	if m == nil {
+		return nil
+	}
+	x := &{{.Name}}{}
+{{- range .Fields}}
+	{{.ToProto}}
+{{- end}}
+	return x
+}
+
+// ToModel convert {{.Name}} message to {{.ModelPkg}}.{{.Model}}
+func (x *{{.Name}}) ToModel() *{{.ModelPkg}}.{{.Model}} {
+	if x == nil {
+		return nil
+	}
+	m := &{{.ModelPkg}}.{{.Model}}{}
+{{- range .Fields}}
+	{{.ToModel}}
+{{- end}}
+	return m
+}
+`
+
+type (
+	// protoMessage protobuf message of table model
+	protoMessage struct {
+		Name          string
+		Table         string
+		Comment       string
+		Package       string
+		GoPackage     string
+		GoPackageName string
+		ModelPkgPath  string
+		ModelPkg      string
+		Model         string
+		Imports       []string
+		Fields        []protoField
+		Enums         []protoEnum
+	}
+	// protoField protobuf message field with converter statements
+	protoField struct {
+		Name    string
+		Type    string
+		Number  int
+		Comment string
+		ToProto string // assign message x field from model m
+		ToModel string // assign model m field from message x
+	}
+	// protoEnum protobuf enum of enum column
+	protoEnum struct {
+		Name      string
+		FromModel string
+		ToModel   string
+		Values    []protoEnumValue
+	}
+	protoEnumValue struct {
+		Name   string
+		Number int
+		Value  string
+	}
+	// protoScalar protobuf scalar of go type
+	protoScalar struct {
+		Type    string // protobuf type
+		GoType  string // go type of protobuf generated code
+		Wrapper string // wrapperspb constructor and google.protobuf wrapper type prefix
+	}
+)
+
+// protoScalars protobuf scalar of go field type
+var protoScalars = map[string]protoScalar{
+	"int8":                  {"int32", "int32", "Int32"},
+	"int16":                 {"int32", "int32", "Int32"},
+	"int32":                 {"int32", "int32", "Int32"},
+	"int":                   {"int64", "int64", "Int64"},
+	"int64":                 {"int64", "int64", "Int64"},
+	"uint8":                 {"uint32", "uint32", "UInt32"},
+	"uint16":                {"uint32", "uint32", "UInt32"},
+	"uint32":                {"uint32", "uint32", "UInt32"},
+	"uint":                  {"uint64", "uint64", "UInt64"},
+	"uint64":                {"uint64", "uint64", "UInt64"},
+	"soft_delete.DeletedAt": {"uint64", "uint64", "UInt64"},
+	"float32":               {"float", "float32", "Float"},
+	"float64":               {"double", "float64", "Double"},
+	"bool":                  {"bool", "bool", "Bool"},
+	"string":                {"string", "string", "String"},
+	"[]byte":                {"bytes", "[]byte", "Bytes"},
+	"datatypes.JSON":        {"bytes", "[]byte", "Bytes"},
+	"json.RawMessage":       {"bytes", "[]byte", "Bytes"},
+}
+
+// protoRepeated protobuf scalar of repeated field of lib/pq array type
+var protoRepeated = map[string]protoScalar{
+	"pq.StringArray":  {Type: "string", GoType: "[]string"},
+	"pq.Int32Array":   {Type: "int32", GoType: "[]int32"},
+	"pq.Int64Array":   {Type: "int64", GoType: "[]int64"},
+	"pq.Float32Array": {Type: "float", GoType: "[]float32"},
+	"pq.Float64Array": {Type: "double", GoType: "[]float64"},
+	"pq.BoolArray":    {Type: "bool", GoType: "[]bool"},
+	"pq.ByteaArray":   {Type: "bytes", GoType: "[][]byte"},
+}
+
+// GenProtos generate protobuf messages of tables and converters of models
+func (g *GenTools) GenProtos(models []tableModel) error {
+	if !g.params.WithProto || len(models) == 0 {
+		return nil
+	}
+	dir, err := filepath.Abs(g.params.GetProtoOutPath(g.g.OutPath))
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create proto out path %s fail: %w", dir, err)
+	}
+	modelPkgPath, err := g.modelPkgPath()
+	if err != nil {
+		return err
+	}
+	goPackage, err := g.protoGoPackage(dir, modelPkgPath)
+	if err != nil {
+		return err
+	}
+	goPackageName := goPackageName(goPackage)
+	protoPackage := g.params.ProtoPackage
+	if protoPackage == "" {
+		protoPackage = goPackageName
+	}
+	for _, m := range models {
+		msg, err := newProtoMessage(m)
+		if err != nil {
+			return err
+		}
+		msg.Package, msg.ModelPkgPath = protoPackage, modelPkgPath
+		msg.GoPackage, msg.GoPackageName = goPackage+";"+goPackageName, goPackageName
+		content, err := render(protoFileTmpl, msg)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(dir, m.FileName+".proto"), content, 0640); err != nil {
+			return err
+		}
+		if content, err = render(protoConvertTmpl, msg); err != nil {
+			return err
+		}
+		if err = outputGoFile(filepath.Join(dir, m.FileName+".convert.go"), content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoGoPackage go import path of protobuf generated code, default relative to model import path
+func (g *GenTools) protoGoPackage(dir, modelPkgPath string) (string, error) {
+	if g.params.ProtoGoPackage != "" {
+		return g.params.ProtoGoPackage, nil
+	}
+	modelPath, err := modelOutputPath(g.g.OutPath, g.g.ModelPkgPath)
+	if err != nil {
+		return "", err
+	}
+	if modelPath, err = filepath.Abs(modelPath); err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(modelPath, dir)
+	if err != nil {
+		return "", fmt.Errorf("parse proto go package fail: %w", err)
+	}
+	return path.Join(modelPkgPath, filepath.ToSlash(rel)), nil
+}
+
+// goPackageName go package name of import path, eg: example.com/api/proto => proto
+func goPackageName(importPath string) string {
+	name := protoIdent(strings.ToLower(path.Base(importPath)))
+	if name == "" || name == "_" {
+		return "pb"
+	}
+	return name
+}
+
+// newProtoMessage protobuf message of table model, error on field without protobuf type
+func newProtoMessage(m tableModel) (protoMessage, error) {
+	var (
+		msg = protoMessage{
+			Name:     m.ModelStructName,
+			Table:    m.TableName,
+			Comment:  protoComment(m.TableComment),
+			ModelPkg: m.ModelPkg,
+			Model:    m.ModelStructName,
+		}
+		imports = make(map[string]bool)
+		number  int
+	)
+	for _, f := range m.Fields {
+		column, ok := m.Column(f)
+		if !ok {
+			continue
+		}
+		var (
+			name     = protoIdent(strings.ToLower(f.ColumnName))
+			goName   = protoGoName(name)
+			pointer  = strings.HasPrefix(f.Type, "*")
+			typ      = strings.TrimPrefix(f.Type, "*")
+			nullable bool
+			field    = protoField{Name: name, Comment: protoComment(f.ColumnComment)}
+		)
+		if v, ok := column.Nullable(); ok && v {
+			nullable = true
+		}
+		if pk, _ := column.PrimaryKey(); pk {
+			nullable = false
+		}
+		if values := m.EnumValues(column); len(values) > 0 && typ == "string" {
+			enum := newProtoEnum(msg.Name+goName, values)
+			msg.Enums = append(msg.Enums, enum)
+			field.Type = enum.Name
+			if pointer {
+				field.ToProto = fmt.Sprintf("if m.%s != nil {\n\t\tx.%s = %s[*m.%s]\n\t}", f.Name, goName, enum.FromModel, f.Name)
+				field.ToModel = fmt.Sprintf("if v, ok := %s[x.%s]; ok {\n\t\tm.%s = &v\n\t}", enum.ToModel, goName, f.Name)
+			} else {
+				field.ToProto = fmt.Sprintf("x.%s = %s[m.%s]", goName, enum.FromModel, f.Name)
+				field.ToModel = fmt.Sprintf("m.%s = %s[x.%s]", f.Name, enum.ToModel, goName)
+			}
+		} else if !protoNullField(&field, f.Name, goName, typ) &&
+			!protoTimeField(&field, f.Name, goName, typ, pointer) &&
+			!protoScalarField(&field, f.Name, goName, typ, pointer, nullable) &&
+			!protoRepeatedField(&field, f.Name, goName, f.Type) {
+			return msg, fmt.Errorf("field %s.%s of type %s has no protobuf type, map the column by typeMappings to a supported type",
+				m.TableName, f.ColumnName, f.Type)
+		}
+		switch {
+		case field.Type == protoTimestampType:
+			imports[protoTimestampImport] = true
+		case strings.HasPrefix(field.Type, "google.protobuf."):
+			imports[protoWrappersImport] = true
+		}
+		number++
+		field.Number = number
+		msg.Fields = append(msg.Fields, field)
+	}
+	for _, v := range []string{protoTimestampImport, protoWrappersImport} {
+		if imports[v] {
+			msg.Imports = append(msg.Imports, v)
+		}
+	}
+	return msg, nil
+}
+
+// protoNullField convert database/sql and guregu/null field to google.protobuf wrappers or Timestamp, invalid value is unset
+func protoNullField(field *protoField, name, goName, typ string) bool {
+	valueName, valueType, ok := config.NullValue(typ)
+	if !ok {
+		return false
+	}
+	value := "m." + name + "." + valueName
+	if valueType == "time.Time" {
+		field.Type = protoTimestampType
+		field.ToProto = fmt.Sprintf("if m.%s.Valid {\n\t\tx.%s = timestamppb.New(%s)\n\t}", name, goName, value)
+		field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\t%s, m.%s.Valid = x.%s.AsTime(), true\n\t}", goName, value, name, goName)
+		return true
+	}
+	scalar, ok := protoScalars[valueType]
+	if !ok {
+		return false
+	}
+	field.Type = "google.protobuf." + scalar.Wrapper + "Value"
+	field.ToProto = fmt.Sprintf("if m.%s.Valid {\n\t\tx.%s = wrapperspb.%s(%s)\n\t}",
+		name, goName, scalar.Wrapper, protoCast(scalar.GoType, valueType, value))
+	field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\t%s, m.%s.Valid = %s, true\n\t}",
+		goName, value, name, protoCast(valueType, scalar.GoType, "x."+goName+".Value"))
+	return true
+}
+
+// protoTimeField convert time field to google.protobuf.Timestamp
+func protoTimeField(field *protoField, name, goName, typ string, pointer bool) bool {
+	switch {
+	case typ == "gorm.DeletedAt":
+		field.ToProto = fmt.Sprintf("if m.%s.Valid {\n\t\tx.%s = timestamppb.New(m.%s.Time)\n\t}", name, goName, name)
+		field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\tm.%s = gorm.DeletedAt{Time: x.%s.AsTime(), Valid: true}\n\t}", goName, name, goName)
+	case typ == "time.Time" && pointer:
+		field.ToProto = fmt.Sprintf("if m.%s != nil {\n\t\tx.%s = timestamppb.New(*m.%s)\n\t}", name, goName, name)
+		field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\tv := x.%s.AsTime()\n\t\tm.%s = &v\n\t}", goName, goName, name)
+	case typ == "time.Time":
+		field.ToProto = fmt.Sprintf("x.%s = timestamppb.New(m.%s)", goName, name)
+		field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\tm.%s = x.%s.AsTime()\n\t}", goName, name, goName)
+	default:
+		return false
+	}
+	field.Type = protoTimestampType
+	return true
+}
+
+// protoScalarField convert scalar field, nullable column and pointer field use google.protobuf wrappers
+func protoScalarField(field *protoField, name, goName, typ string, pointer, nullable bool) bool {
+	if typ == "optimisticlock.Version" {
+		field.Type = "int64"
+		field.ToProto = fmt.Sprintf("x.%s = m.%s.Int64", goName, name)
+		field.ToModel = fmt.Sprintf("m.%s = optimisticlock.Version{Int64: x.%s, Valid: true}", name, goName)
+		return true
+	}
+	scalar, ok := protoScalars[typ]
+	if !ok {
+		return false
+	}
+	var (
+		toProto = func(v string) string { return protoCast(scalar.GoType, typ, v) }
+		toModel = func(v string) string { return protoCast(typ, scalar.GoType, v) }
+	)
+	switch {
+	case pointer:
+		field.Type = "google.protobuf." + scalar.Wrapper + "Value"
+		field.ToProto = fmt.Sprintf("if m.%s != nil {\n\t\tx.%s = wrapperspb.%s(%s)\n\t}", name, goName, scalar.Wrapper, toProto("*m."+name))
+		field.ToModel = fmt.Sprintf("if x.%s != nil {\n\t\tv := %s\n\t\tm.%s = &v\n\t}", goName, toModel("x."+goName+".Value"), name)
+	case nullable:
+		field.Type = "google.protobuf." + scalar.Wrapper + "Value"
+		field.ToProto = fmt.Sprintf("x.%s = wrapperspb.%s(%s)", goName, scalar.Wrapper, toProto("m."+name))
+		field.ToModel = fmt.Sprintf("m.%s = %s", name, toModel("x."+goName+".GetValue()"))
+	default:
+		field.Type = scalar.Type
+		field.ToProto = fmt.Sprintf("x.%s = %s", goName, toProto("m."+name))
+		field.ToModel = fmt.Sprintf("m.%s = %s", name, toModel("x."+goName))
+	}
+	return true
+}
+
+// protoRepeatedField convert lib/pq array field to repeated field
+func protoRepeatedField(field *protoField, name, goName, typ string) bool {
+	scalar, ok := protoRepeated[typ]
+	if !ok {
+		return false
+	}
+	field.Type = "repeated " + scalar.Type
+	field.ToProto = fmt.Sprintf("x.%s = %s", goName, protoCast(scalar.GoType, typ, "m."+name))
+	field.ToModel = fmt.Sprintf("m.%s = %s", name, protoCast(typ, scalar.GoType, "x."+goName))
+	return true
+}
+
+// protoCast convert value between go types
+func protoCast(to, from, value string) string {
+	if to == from {
+		return value
+	}
+	if strings.HasPrefix(to, "[]") {
+		return fmt.Sprintf("(%s)(%s)", to, value)
+	}
+	return fmt.Sprintf("%s(%s)", to, value)
+}
+
+// newProtoEnum protobuf enum of enum column values, zero value is unspecified
+func newProtoEnum(name string, values []string) protoEnum {
+	var (
+		prefix = protoUpperSnake(name) + "_"
+		enum   = protoEnum{
+			Name:      name,
+			FromModel: protoLowerFirst(name) + "FromModel",
+			ToModel:   protoLowerFirst(name) + "ToModel",
+			Values:    []protoEnumValue{{Name: prefix + "UNSPECIFIED"}},
+		}
+		names = map[string]bool{prefix + "UNSPECIFIED": true}
+	)
+	for i, v := range values {
+		valueName := prefix + strings.ToUpper(strings.Trim(protoIdent(v), "_"))
+		if valueName == prefix {
+			valueName = prefix + "EMPTY"
+		}
+		if names[valueName] {
+			valueName = fmt.Sprintf("%s_%d", valueName, i+1)
+		}
+		names[valueName] = true
+		enum.Values = append(enum.Values, protoEnumValue{Name: valueName, Number: i + 1, Value: v})
+	}
+	return enum
+}
+
+// protoIdent replace chars not allowed in protobuf identifier, eg: user-name => user_name
+func protoIdent(name string) string {
+	var buf strings.Builder
+	for i, r := range name {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || r == '_'):
+			buf.WriteRune(r)
+		case r < unicode.MaxASCII && unicode.IsDigit(r):
+			if i == 0 {
+				buf.WriteRune('_')
+			}
+			buf.WriteRune(r)
+		default:
+			buf.WriteRune('_')
+		}
+	}
+	return buf.String()
+}
+
+// protoGoName go field name of protobuf field, same as protoc-gen-go, eg: user_id => UserId
+func protoGoName(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && 'a' <= name[i+1] && name[i+1] <= 'z':
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && 'a' <= name[i+1] && name[i+1] <= 'z'; i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// protoUpperSnake upper snake case of camel case name, eg: OrderStatus => ORDER_STATUS
+func protoUpperSnake(name string) string {
+	var (
+		buf   strings.Builder
+		runes = []rune(name)
+	)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			buf.WriteRune('_')
+		}
+		buf.WriteRune(unicode.ToUpper(r))
+	}
+	return buf.String()
+}
+
+// protoLowerFirst lower case first letter
+func protoLowerFirst(name string) string {
+	runes := []rune(name)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// protoComment single line comment
+func protoComment(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"database/sql"
+	"testing"
+
+	gen "gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+func TestNewProtoMessage(t *testing.T) {
+	cases := []struct {
+		name       string
+		goType     string
+		dbType     string
+		columnType string
+		nullable   bool
+		enumTypes  map[string][]string
+		want       string
+		toModel    string
+		error      bool
+	}{
+		{name: "int64", goType: "int64", dbType: "bigint", columnType: "bigint", want: "int64", toModel: "m.Status = x.Status"},
+		{name: "nullable pointer", goType: "*string", dbType: "varchar", columnType: "varchar(64)", nullable: true, want: "google.protobuf.StringValue"},
+		{name: "time", goType: "time.Time", dbType: "timestamptz", columnType: "timestamp with time zone", want: protoTimestampType},
+		{name: "mysql enum", goType: "string", dbType: "enum", columnType: "enum('paid','refunded')", want: "OrderStatus"},
+		{
+			name: "postgres enum", goType: "string", dbType: "order_status", columnType: "order_status",
+			enumTypes: map[string][]string{"order_status": {"paid", "refunded"}}, want: "OrderStatus",
+		},
+		{name: "postgres enum without values", goType: "string", dbType: "order_status", columnType: "order_status", want: "string"},
+		{name: "string array", goType: "pq.StringArray", dbType: "_text", columnType: "text[]", want: "repeated string", toModel: "m.Status = pq.StringArray(x.Status)"},
+		{name: "int64 array", goType: "pq.Int64Array", dbType: "_int8", columnType: "bigint[]", want: "repeated int64"},
+		{name: "sql null string", goType: "sql.NullString", dbType: "varchar", columnType: "varchar(64)", nullable: true, want: "google.protobuf.StringValue", toModel: "if x.Status != nil {\n\t\tm.Status.String, m.Status.Valid = x.Status.Value, true\n\t}"},
+		{name: "sql null int16", goType: "sql.NullInt16", dbType: "smallint", columnType: "smallint", nullable: true, want: "google.protobuf.Int32Value", toModel: "if x.Status != nil {\n\t\tm.Status.Int16, m.Status.Valid = int16(x.Status.Value), true\n\t}"},
+		{name: "generic sql null", goType: "sql.Null[int64]", dbType: "bigint", columnType: "bigint", nullable: true, want: "google.protobuf.Int64Value"},
+		{name: "guregu string", goType: "null.String", dbType: "varchar", columnType: "varchar(64)", nullable: true, want: "google.protobuf.StringValue"},
+		{name: "guregu time", goType: "null.Time", dbType: "timestamptz", columnType: "timestamp with time zone", nullable: true, want: protoTimestampType},
+		{name: "guregu value", goType: "null.Value[float32]", dbType: "real", columnType: "real", nullable: true, want: "google.protobuf.FloatValue"},
+		{name: "guregu value without protobuf type", goType: "null.Value[uuid.UUID]", dbType: "uuid", columnType: "uuid", nullable: true, error: true},
+		{name: "uuid", goType: "uuid.UUID", dbType: "uuid", columnType: "uuid", error: true},
+		{name: "pgtype", goType: "pgtype.Interval", dbType: "interval", columnType: "interval", error: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newField("Status", "status", false)
+			f.Type = c.goType
+			column := newProtoColumn("status", c.dbType, c.columnType, c.nullable)
+			m := newTableModel("orders", "Order", "model", "orders", "", []gen.Field{f}, []gorm.ColumnType{column})
+			m.EnumTypes = c.enumTypes
+			msg, err := newProtoMessage(m)
+			if c.error {
+				if err == nil {
+					t.Fatalf("newProtoMessage(%s) error = nil, want error", c.goType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newProtoMessage(%s) error = %v", c.goType, err)
+			}
+			if len(msg.Fields) != 1 || msg.Fields[0].Type != c.want {
+				t.Fatalf("newProtoMessage(%s) fields = %+v, want type %s", c.goType, msg.Fields, c.want)
+			}
+			if c.want == "OrderStatus" && (len(msg.Enums) != 1 || len(msg.Enums[0].Values) != 3) {
+				t.Errorf("newProtoMessage(%s) enums = %+v, want paid and refunded", c.goType, msg.Enums)
+			}
+			if c.toModel != "" && msg.Fields[0].ToModel != c.toModel {
+				t.Errorf("newProtoMessage(%s) to model = %q, want %q", c.goType, msg.Fields[0].ToModel, c.toModel)
+			}
+		})
+	}
+}
+
+func newProtoColumn(name, dbType, columnType string, nullable bool) gorm.ColumnType {
+	return migrator.ColumnType{
+		NameValue:       sql.NullString{String: name, Valid: true},
+		DataTypeValue:   sql.NullString{String: dbType, Valid: true},
+		ColumnTypeValue: sql.NullString{String: columnType, Valid: true},
+		NullableValue:   sql.NullBool{Bool: nullable, Valid: true},
+	}
+}
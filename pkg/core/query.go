@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gen "gorm.io/gen"
+)
+
+// queryModel model info of generated query code
+type queryModel struct {
+	S               string
+	QueryStructName string
+	ModelStructName string
+	ModelPkg        string
+	TableName       string
+	Fields          []gen.Field
+}
+
+// queryModels models of generated query code sort by name
+func (g *GenTools) queryModels() []queryModel {
+	var (
+		names  = make([]string, 0, len(g.g.Data))
+		models = make([]queryModel, 0, len(g.g.Data))
+	)
+	for name := range g.g.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		info := g.g.Data[name]
+		if !info.Generated {
+			continue
+		}
+		models = append(models, queryModel{
+			S:               info.S,
+			QueryStructName: info.QueryStructName,
+			ModelStructName: info.ModelStructName,
+			ModelPkg:        info.StructInfo.Package,
+			TableName:       info.TableName,
+			Fields:          info.Fields,
+		})
+	}
+	return models
+}
+
+// queryPkg package name and model import path of generated query code
+func (g *GenTools) queryPkg() (pkgName, modelPkgPath string, err error) {
+	if modelPkgPath, err = g.modelPkgPath(); err != nil {
+		return "", "", err
+	}
+	return filepath.Base(g.g.OutPath), modelPkgPath, nil
+}
+
+// modelPkgPath import path of generated model code
+func (g *GenTools) modelPkgPath() (string, error) {
+	modelPath, err := modelOutputPath(g.g.OutPath, g.g.ModelPkgPath)
+	if err != nil {
+		return "", err
+	}
+	pkgPath, err := loadPkgPath(modelPath)
+	if err != nil {
+		return "", fmt.Errorf("parse model pkg path fail: %w", err)
+	}
+	return pkgPath, nil
+}
+
+// queryFileName query code file name with suffix, eg: gen_batch.go
+func (g *GenTools) queryFileName(suffix string) string {
+	return strings.TrimSuffix(g.g.OutFile, ".go") + "_" + suffix + ".go"
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+// generatedMark header of files written by gentool
+const generatedMark = "// Code generated by gorm-tools. DO NOT EDIT.\n"
+
+// render execute text template with data
+func render(tmpl string, data interface{}) ([]byte, error) {
+	return renderWithFuncs(tmpl, nil, data)
+}
+
+// renderWithFuncs execute text template with funcs and data
+func renderWithFuncs(tmpl string, funcs template.FuncMap, data interface{}) ([]byte, error) {
+	t, err := template.New("").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderHTMLWithFuncs execute html template with funcs and data, values are escaped
+func renderHTMLWithFuncs(tmpl string, funcs template.FuncMap, data interface{}) ([]byte, error) {
+	t, err := htmltemplate.New("").Funcs(htmltemplate.FuncMap(funcs)).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// outputGoFile format go source and write to file
+func outputGoFile(fileName string, content []byte) error {
+	result, err := imports.Process(fileName, content, nil)
+	if err != nil {
+		return fmt.Errorf("cannot format file %s: %w", fileName, err)
+	}
+	return os.WriteFile(fileName, result, 0640)
+}
+
+// modelOutputPath model code directory, same rule as gorm.io/gen
+func modelOutputPath(outPath, modelPkgPath string) (string, error) {
+	if strings.Contains(modelPkgPath, string(os.PathSeparator)) {
+		return filepath.Abs(modelPkgPath)
+	}
+	return filepath.Join(filepath.Dir(outPath), modelPkgPath), nil
+}
+
+// loadPkgPath go import path of directory
+func loadPkgPath(dir string) (string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName,
+		Dir:  dir,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(pkgs) == 0 {
+		return "", fmt.Errorf("no package found in %s", dir)
+	}
+	return pkgs[0].PkgPath, nil
+}
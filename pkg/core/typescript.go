@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+)
+
+const (
+	typeScriptFileName  = "models.d.ts"
+	typeScriptIndexName = "index.d.ts"
+	typeScriptUnknown   = "unknown"
+)
+
+const typeScriptFileTmpl = `// Code generated by gorm-tools. DO NOT EDIT.
+{{range .}}
+/**
+ * {{.Name}} mapped from table <{{.Table}}>{{if .Comment}}
+ * {{.Comment}}{{end}}
+ */
+export interface {{.Name}} {
+{{- range .Fields}}{{if .Comment}}
+  /** {{.Comment}} */{{end}}
+  {{.Name}}{{if .Optional}}?{{end}}: {{.Type}};
+{{- end}}
+}
+{{end}}`
+
+type (
+	// typeScriptInterface typescript interface of model
+	typeScriptInterface struct {
+		Name    string
+		Table   string
+		Comment string
+		Fields  []typeScriptField
+	}
+	typeScriptField struct {
+		Name     string
+		Type     string
+		Optional bool
+		Comment  string
+	}
+)
+
+// typeScriptTypes typescript type of go field type, types not found are unknown
+var typeScriptTypes = map[string]string{
+	"int8":                   "number",
+	"int16":                  "number",
+	"int32":                  "number",
+	"int":                    "number",
+	"int64":                  "number",
+	"uint8":                  "number",
+	"uint16":                 "number",
+	"uint32":                 "number",
+	"uint":                   "number",
+	"uint64":                 "number",
+	"float32":                "number",
+	"float64":                "number",
+	"soft_delete.DeletedAt":  "number",
+	"optimisticlock.Version": "number",
+	"bool":                   "boolean",
+	"string":                 "string",
+	"[]byte":                 "string",
+	"time.Time":              "string",
+	"gorm.DeletedAt":         "string",
+	"datatypes.Date":         "string",
+	"datatypes.Time":         "string",
+	"datatypes.UUID":         "string",
+}
+
+// typeScriptSQLNull typescript object of database/sql null type, no json marshaler, eg: { String: string; Valid: boolean }
+const typeScriptSQLNull = "{ %s: %s; Valid: boolean }"
+
+var typeScriptIdentReg = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// GenTypeScript generate typescript interfaces of models
+func (g *GenTools) GenTypeScript(models []tableModel) error {
+	if !g.params.WithTypeScript || len(models) == 0 {
+		return nil
+	}
+	dir := g.params.GetTypeScriptOutPath(g.g.OutPath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("create typescript out path %s fail: %w", dir, err)
+	}
+	var interfaces = make([]typeScriptInterface, 0, len(models))
+	for _, m := range models {
+		interfaces = append(interfaces, newTypeScriptInterface(m))
+	}
+	if !g.params.TypeScriptPerTable {
+		return writeTypeScriptFile(filepath.Join(dir, typeScriptFileName), interfaces)
+	}
+	var index = strings.Builder{}
+	index.WriteString("// Code generated by gorm-tools. DO NOT EDIT.\n\n")
+	for i, m := range models {
+		if err := writeTypeScriptFile(filepath.Join(dir, m.FileName+".d.ts"), interfaces[i:i+1]); err != nil {
+			return err
+		}
+		index.WriteString(fmt.Sprintf("export * from './%s';\n", m.FileName))
+	}
+	return os.WriteFile(filepath.Join(dir, typeScriptIndexName), []byte(index.String()), 0640)
+}
+
+func writeTypeScriptFile(fileName string, interfaces []typeScriptInterface) error {
+	content, err := render(typeScriptFileTmpl, interfaces)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0640)
+}
+
+// newTypeScriptInterface typescript interface of model, json omitempty field is optional,
+// field marshaled as null is union with null, eg: *string => string | null
+func newTypeScriptInterface(m tableModel) typeScriptInterface {
+	var ts = typeScriptInterface{
+		Name:    m.ModelStructName,
+		Table:   m.TableName,
+		Comment: typeScriptComment(m.TableComment),
+	}
+	for _, f := range m.Fields {
+		column, ok := m.Column(f)
+		if !ok {
+			continue
+		}
+		name, omitempty, ok := jsonProperty(f)
+		if !ok {
+			continue
+		}
+		var (
+			typ, nullable                 = jsonValueType(f.Type)
+			valueName, valueType, sqlNull = jsonSQLNullValue(typ)
+			comment, _                    = column.Comment()
+			field                         = typeScriptField{Name: name, Optional: omitempty, Comment: typeScriptComment(comment)}
+		)
+		if sqlNull {
+			typ = valueType
+		} else if _, valueType, ok := config.NullValue(typ); ok {
+			typ = valueType
+		}
+		if !typeScriptIdentReg.MatchString(name) {
+			field.Name = strconv.Quote(name)
+		}
+		if values := config.EnumValues(column); len(values) > 0 {
+			for i, v := range values {
+				values[i] = "'" + strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), "'", `\'`) + "'"
+			}
+			field.Type = strings.Join(values, " | ")
+		} else if field.Type = typeScriptTypes[typ]; field.Type == "" {
+			field.Type = typeScriptUnknown
+		}
+		if nullable && field.Type != typeScriptUnknown {
+			field.Type += " | null"
+		}
+		if sqlNull {
+			field.Type = fmt.Sprintf(typeScriptSQLNull, valueName, field.Type)
+		}
+		ts.Fields = append(ts.Fields, field)
+	}
+	return ts
+}
+
+// typeScriptComment single line comment safe in block comment
+func typeScriptComment(comment string) string {
+	return strings.ReplaceAll(protoComment(comment), "*/", "*\\/")
+}
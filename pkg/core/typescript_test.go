@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+
+	gen "gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+func TestNewTypeScriptInterface(t *testing.T) {
+	cases := []struct {
+		name     string
+		goType   string
+		nullable bool
+		want     string
+	}{
+		{name: "not null", goType: "int64", want: "number"},
+		{name: "pointer of nullable column", goType: "*string", nullable: true, want: "string | null"},
+		{name: "value of nullable column", goType: "string", nullable: true, want: "string"},
+		{name: "deleted at", goType: "gorm.DeletedAt", nullable: true, want: "string | null"},
+		{name: "sql null string", goType: "sql.NullString", nullable: true, want: "{ String: string; Valid: boolean }"},
+		{name: "generic sql null", goType: "sql.Null[time.Time]", nullable: true, want: "{ V: string; Valid: boolean }"},
+		{name: "guregu string", goType: "null.String", nullable: true, want: "string | null"},
+		{name: "guregu value", goType: "null.Value[int64]", nullable: true, want: "number | null"},
+		{name: "unknown", goType: "*uuid.UUID", nullable: true, want: typeScriptUnknown},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newField("Nickname", "nickname", false)
+			f.Type = c.goType
+			column := newProtoColumn("nickname", "varchar", "varchar(64)", c.nullable)
+			ts := newTypeScriptInterface(newTableModel("users", "User", "model", "users", "", []gen.Field{f}, []gorm.ColumnType{column}))
+			if len(ts.Fields) != 1 || ts.Fields[0].Type != c.want {
+				t.Errorf("newTypeScriptInterface(%s) fields = %+v, want type %q", c.goType, ts.Fields, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const upsertTmpl = generatedMark + `
+package {{.Package}}
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"{{.ModelPkgPath}}"
+)
+{{range .Models}}{{$m := .}}{{range .Keys}}
+// {{.Method}} insert rows, on conflict of ({{join .Columns ", "}}) {{if .Updates}}update {{join .Updates ", "}}{{else}}do nothing{{end}}
+func ({{$m.S}} {{$m.QueryStructName}}) {{.Method}}(ctx context.Context, rows ...*{{$m.ModelPkg}}.{{$m.ModelStructName}}) error {
+	return {{$m.S}}.{{$m.QueryStructName}}Do.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{ {{range .Columns}}{Name: {{printf "%q" .}}}, {{end}} },
+		{{if .Updates}}DoUpdates: clause.AssignmentColumns([]string{ {{range .Updates}}{{printf "%q" .}}, {{end}} }),{{else}}DoNothing: true,{{end}}
+	}).Create(rows...)
+}
+{{end}}{{end}}`
+
+type (
+	upsertKey struct {
+		Method  string
+		Columns []string
+		Updates []string
+	}
+	upsertModel struct {
+		queryModel
+		Keys []upsertKey
+	}
+)
+
+// GenUpserts generate Upsert methods on primary key and unique keys for query code
+func (g *GenTools) GenUpserts() error {
+	if !g.params.WithUpsert {
+		return nil
+	}
+	var models []upsertModel
+	for _, m := range g.queryModels() {
+		if keys := g.upsertKeys(m); len(keys) > 0 {
+			models = append(models, upsertModel{queryModel: m, Keys: keys})
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	pkgName, modelPkgPath, err := g.queryPkg()
+	if err != nil {
+		return err
+	}
+	content, err := renderWithFuncs(upsertTmpl, template.FuncMap{
+		"join": strings.Join,
+	}, map[string]interface{}{
+		"Package":      pkgName,
+		"ModelPkgPath": modelPkgPath,
+		"Models":       models,
+	})
+	if err != nil {
+		return err
+	}
+	return outputGoFile(g.queryFileName("upsert"), content)
+}
+
+// upsertKeys conflict keys of model, primary key first then unique indexes
+func (g *GenTools) upsertKeys(m queryModel) []upsertKey {
+	var (
+		keys    []upsertKey
+		pk      []string
+		columns = make([]string, 0, len(m.Fields))
+		methods = make(map[string]struct{})
+		ns      = schema.NamingStrategy{}
+	)
+	for _, f := range m.Fields {
+		if f == nil || f.IsRelation() || f.ColumnName == "" {
+			continue
+		}
+		columns = append(columns, f.ColumnName)
+		if _, ok := f.GORMTag[field.TagKeyGormPrimaryKey]; ok {
+			pk = append(pk, f.ColumnName)
+		}
+	}
+	updates := g.params.GetUpsertColumns(m.TableName, columns)
+	appendKey := func(method string, cols []string) {
+		if _, ok := methods[method]; ok || len(cols) == 0 {
+			return
+		}
+		methods[method] = struct{}{}
+		keys = append(keys, upsertKey{
+			Method:  method,
+			Columns: cols,
+			Updates: excludeColumns(excludeColumns(updates, pk), cols),
+		})
+	}
+	appendKey("Upsert", pk)
+	for _, cols := range uniqueIndexColumns(g.GetDB(), m.TableName) {
+		if sameColumns(cols, pk) {
+			continue
+		}
+		appendKey("UpsertBy"+ns.SchemaName(strings.Join(cols, "_")), cols)
+	}
+	return keys
+}
+
+// uniqueIndexColumns columns of unique indexes of table, primary key index excluded
+func uniqueIndexColumns(db *gorm.DB, table string) [][]string {
+	indexes, err := db.Migrator().GetIndexes(table)
+	if err != nil {
+		log.Printf("get indexes of table %s fail: %s", table, err.Error())
+		return nil
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name() < indexes[j].Name() })
+	var values [][]string
+	for _, idx := range indexes {
+		if pk, _ := idx.PrimaryKey(); pk {
+			continue
+		}
+		if uk, _ := idx.Unique(); uk && len(idx.Columns()) > 0 {
+			values = append(values, idx.Columns())
+		}
+	}
+	return values
+}
+
+func excludeColumns(columns, excludes []string) []string {
+	values := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !containsColumn(excludes, c) {
+			values = append(values, c)
+		}
+	}
+	return values
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, c := range a {
+		if !containsColumn(b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/VDHewei/gorm-tools/pkg/config"
+	"golang.org/x/tools/go/packages"
+)
+
+// RunValidate check yaml config of -c by validate command, exit with code 1 on errors
+func (g *GenTools) RunValidate() bool {
+	if !g.params.IsCommand(config.CommandValidate) {
+		return false
+	}
+	path := g.params.GetConfigPath()
+	if path == "" {
+		log.Fatalln("validate command require config option")
+		return true
+	}
+	issues, err := config.ValidateYAMLConfig(path, importablePkgPath)
+	if err != nil {
+		log.Fatalln("validate config fail:", err)
+		return true
+	}
+	if errs := writeConfigIssues(os.Stdout, path, issues); errs > 0 {
+		os.Exit(1)
+	}
+	return true
+}
+
+// writeConfigIssues write issues as file:line:column: level: message, returns count of errors
+func writeConfigIssues(w io.Writer, path string, issues []config.ConfigIssue) int {
+	var errs, warnings int
+	for _, v := range issues {
+		_, _ = fmt.Fprintf(w, "%s:%d:%d: %s: %s\n", path, v.Line, v.Column, v.Level, v.Message)
+		if v.Level == config.LintLevelError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintf(w, "%s is valid\n", path)
+	} else {
+		_, _ = fmt.Fprintf(w, "%d problems (%d errors, %d warnings)\n", len(issues), errs, warnings)
+	}
+	return errs
+}
+
+// importablePkgPath whether package path can be imported by module of working directory
+func importablePkgPath(pkgPath string) error {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, pkgPath)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return errors.New("package not found")
+	}
+	for _, e := range pkgs[0].Errors {
+		msg, _, _ := strings.Cut(e.Msg, "\n")
+		return errors.New(msg)
+	}
+	return nil
+}